@@ -48,7 +48,7 @@ func (src *DBaaSInventory) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.ProviderRef = v1beta1.NamespacedName(src.Spec.ProviderRef)
 
 	// Status
-	src.Status.ConvertTo(&dst.Status)
+	src.Status.ConvertTo(&dst.Status.DBaaSInventoryStatus)
 
 	return nil
 }
@@ -70,7 +70,7 @@ func (dst *DBaaSInventory) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.ConvertFrom(&src.Spec)
 
 	// Status
-	dst.Status.ConvertFrom(&src.Status)
+	dst.Status.ConvertFrom(&src.Status.DBaaSInventoryStatus)
 
 	return nil
 }
@@ -42,7 +42,7 @@ func (src *DBaaSPolicy) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	// Status
-	dst.Status = v1beta1.DBaaSPolicyStatus(src.Status)
+	dst.Status.Conditions = src.Status.Conditions
 
 	return nil
 }
@@ -66,7 +66,7 @@ func (dst *DBaaSPolicy) ConvertFrom(srcRaw conversion.Hub) error {
 	}
 
 	// Status
-	dst.Status = DBaaSPolicyStatus(src.Status)
+	dst.Status.Conditions = src.Status.Conditions
 
 	return nil
 }
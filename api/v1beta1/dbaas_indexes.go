@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Field indexes of the DBaaS objects, shared by the webhooks and the controllers
+const (
+	// InventoryProviderIndex indexes the inventories by the name of their provider
+	InventoryProviderIndex = "spec.providerRef.name"
+	// InstanceInventoryNamespaceIndex indexes the instances by the namespace of their inventory
+	InstanceInventoryNamespaceIndex = "spec.inventoryRef.namespace"
+	// InventoryRefIndex indexes the connections and the instances by the namespace/name key of their inventory
+	InventoryRefIndex = "spec.inventoryRef"
+	// ConnectionInstanceIndex indexes the connections by the namespace/name key of the instance they reference
+	ConnectionInstanceIndex = "spec.databaseServiceRef"
+	// NsSelectorIndex indexes the inventories and the policies selecting their connection namespaces by labels,
+	// the only indexed value is "true"
+	NsSelectorIndex = "spec.connections.nsSelector"
+)
+
+// inventoryRefKey returns the namespace/name key of the inventory referenced by an object in the given namespace
+func inventoryRefKey(ref NamespacedName, namespace string) string {
+	if len(ref.Namespace) > 0 {
+		namespace = ref.Namespace
+	}
+	return types.NamespacedName{Name: ref.Name, Namespace: namespace}.String()
+}
+
+// SetupIndexes adds the field indexes of the DBaaS objects to the manager.
+// It is called once, before the webhooks and the controllers are set up.
+func SetupIndexes(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSInventory{}, InventoryProviderIndex, func(rawObj client.Object) []string {
+		inventory := rawObj.(*DBaaSInventory)
+		return []string{inventory.Spec.ProviderRef.Name}
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSInstance{}, InstanceInventoryNamespaceIndex, func(rawObj client.Object) []string {
+		return []string{GetInstanceInventoryNamespace(rawObj.(*DBaaSInstance))}
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSInstance{}, InventoryRefIndex, func(rawObj client.Object) []string {
+		instance := rawObj.(*DBaaSInstance)
+		return []string{inventoryRefKey(instance.Spec.InventoryRef, instance.Namespace)}
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSConnection{}, InventoryRefIndex, func(rawObj client.Object) []string {
+		connection := rawObj.(*DBaaSConnection)
+		return []string{inventoryRefKey(connection.Spec.InventoryRef, connection.Namespace)}
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSInventory{}, NsSelectorIndex, func(rawObj client.Object) []string {
+		inventory := rawObj.(*DBaaSInventory)
+		if inventory.Spec.Policy == nil || inventory.Spec.Policy.Connections.NsSelector == nil {
+			return nil
+		}
+		return []string{"true"}
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSPolicy{}, NsSelectorIndex, func(rawObj client.Object) []string {
+		if rawObj.(*DBaaSPolicy).Spec.Connections.NsSelector == nil {
+			return nil
+		}
+		return []string{"true"}
+	}); err != nil {
+		return err
+	}
+	return mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSConnection{}, ConnectionInstanceIndex, func(rawObj client.Object) []string {
+		connection := rawObj.(*DBaaSConnection)
+		if connection.Spec.DatabaseServiceRef == nil || len(connection.Spec.DatabaseServiceRef.Name) == 0 || connection.Spec.DatabaseServiceType != nil {
+			return nil
+		}
+		namespace := connection.Spec.DatabaseServiceRef.Namespace
+		if len(namespace) == 0 {
+			namespace = connection.Namespace
+		}
+		return []string{types.NamespacedName{Name: connection.Spec.DatabaseServiceRef.Name, Namespace: namespace}.String()}
+	})
+}
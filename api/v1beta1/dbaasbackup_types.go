@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// DBaaSBackup defines the schema for the DBaaSBackup API.
+// +operator-sdk:csv:customresourcedefinitions:displayName="DBaaSBackup"
+type DBaaSBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSBackupSpec   `json:"spec,omitempty"`
+	Status DBaaSBackupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBaaSBackupList contains a list of DBaaSBackups.
+type DBaaSBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSBackup{}, &DBaaSBackupList{})
+}
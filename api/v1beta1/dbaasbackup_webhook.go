@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var dbaasbackuplog = logf.Log.WithName("dbaasbackup-resource")
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *DBaaSBackup) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasbackup,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasbackups,verbs=create;update,versions=v1beta1,name=vdbaasbackup.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DBaaSBackup{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSBackup) ValidateCreate() error {
+	dbaasbackuplog.Info("validate create", "name", r.Name)
+	return r.validateDBaaSBackupSpec()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSBackup) ValidateUpdate(old runtime.Object) error {
+	dbaasbackuplog.Info("validate update", "name", r.Name)
+	oldBackup := old.(*DBaaSBackup)
+	if !reflect.DeepEqual(r.Spec.InventoryRef, oldBackup.Spec.InventoryRef) {
+		return field.Invalid(field.NewPath("spec").Child("inventoryRef"), r.Spec.InventoryRef, "inventoryRef is immutable")
+	}
+	if r.Spec.DatabaseServiceID != oldBackup.Spec.DatabaseServiceID {
+		return field.Invalid(field.NewPath("spec").Child("databaseServiceID"), r.Spec.DatabaseServiceID, "databaseServiceID is immutable")
+	}
+	if !reflect.DeepEqual(r.Spec.DatabaseServiceRef, oldBackup.Spec.DatabaseServiceRef) {
+		return field.Invalid(field.NewPath("spec").Child("databaseServiceRef"), r.Spec.DatabaseServiceRef, "databaseServiceRef is immutable")
+	}
+	return r.validateDBaaSBackupSpec()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSBackup) ValidateDelete() error {
+	dbaasbackuplog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func (r *DBaaSBackup) validateDBaaSBackupSpec() error {
+	if err := validateServiceRef(r.Spec.DatabaseServiceID, r.Spec.DatabaseServiceRef, r.Namespace); err != nil {
+		return err
+	}
+	if len(r.Spec.Schedule) > 0 {
+		if _, err := cron.ParseStandard(r.Spec.Schedule); err != nil {
+			return field.Invalid(field.NewPath("spec").Child("schedule"), r.Spec.Schedule, err.Error())
+		}
+	}
+	if r.Spec.RetentionDays != nil && *r.Spec.RetentionDays <= 0 {
+		return field.Invalid(field.NewPath("spec").Child("retentionDays"), *r.Spec.RetentionDays, "retentionDays must be greater than zero")
+	}
+	return nil
+}
+
+// validateServiceRef checks that a database service is set either by ID or by a reference to a DBaaSInstance
+// in the namespace of the referencing object
+func validateServiceRef(serviceID string, serviceRef *NamespacedName, namespace string) error {
+	if len(serviceID) > 0 && serviceRef != nil && len(serviceRef.Name) > 0 {
+		return field.Invalid(field.NewPath("spec").Child("databaseServiceID"), serviceID, "both databaseServiceID and databaseServiceRef are specified")
+	}
+	if len(serviceID) == 0 && (serviceRef == nil || len(serviceRef.Name) == 0) {
+		return field.Invalid(field.NewPath("spec").Child("databaseServiceID"), serviceID, "either databaseServiceID or databaseServiceRef must be specified")
+	}
+	return validateLocalRef(field.NewPath("spec").Child("databaseServiceRef"), serviceRef, namespace)
+}
+
+// validateLocalRef checks that a reference does not point to another namespace, as backing up or restoring
+// the databases of another namespace is not checked against the policies of that namespace
+func validateLocalRef(path *field.Path, ref *NamespacedName, namespace string) error {
+	if ref != nil && len(ref.Namespace) > 0 && ref.Namespace != namespace {
+		return field.Invalid(path.Child("namespace"), ref.Namespace, "the reference must be in the namespace of the object")
+	}
+	return nil
+}
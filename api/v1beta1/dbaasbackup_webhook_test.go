@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("DBaaSBackup Webhook", func() {
+	DescribeTable("checking invalid DBaaSBackup creations",
+		func(spec DBaaSBackupSpec, expectedErr interface{}) {
+			spec.InventoryRef = NamespacedName{Name: inventoryName, Namespace: testNamespace}
+			backup := &DBaaSBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-backup",
+					Namespace: testNamespace,
+				},
+				Spec: spec,
+			}
+			Expect(k8sClient.Create(ctx, backup)).Should(MatchError(expectedErr))
+		},
+		Entry("not allow a backup without database service",
+			DBaaSBackupSpec{},
+			"admission webhook \"vdbaasbackup.kb.io\" denied the request: "+
+				"spec.databaseServiceID: Invalid value: \"\": either databaseServiceID or databaseServiceRef must be specified"),
+		Entry("not allow a reference to an instance of another namespace",
+			DBaaSBackupSpec{
+				DatabaseServiceRef: &NamespacedName{Name: databaseServiceName, Namespace: testNamespace2},
+			},
+			"admission webhook \"vdbaasbackup.kb.io\" denied the request: "+
+				"spec.databaseServiceRef.namespace: Invalid value: \""+testNamespace2+"\": the reference must be in the namespace of the object"),
+		Entry("not allow an invalid schedule",
+			DBaaSBackupSpec{
+				DatabaseServiceID: databaseServiceID,
+				Schedule:          "every day",
+			},
+			"admission webhook \"vdbaasbackup.kb.io\" denied the request: "+
+				"spec.schedule: Invalid value: \"every day\": expected exactly 5 fields, found 2: [every day]"),
+		Entry("not allow a retention of zero days",
+			DBaaSBackupSpec{
+				DatabaseServiceID: databaseServiceID,
+				RetentionDays:     pointer.Int32(0),
+			},
+			"admission webhook \"vdbaasbackup.kb.io\" denied the request: "+
+				"spec.retentionDays: Invalid value: 0: retentionDays must be greater than zero"),
+	)
+})
@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DBaaSClusterPolicySpec sets the ceilings of the policies of all namespaces.
+// The DBaaSPolicy objects and the inventory policies can only be more restrictive.
+type DBaaSClusterPolicySpec struct {
+	// Disables provisioning on all inventory accounts.
+	DisableProvisions *bool `json:"disableProvisions,omitempty"`
+
+	// Requires the approval of new DBaaSInstance objects for all inventory accounts.
+	RequireApproval *bool `json:"requireApproval,omitempty"`
+
+	// Restricts the providers of all inventories, and the provisioning parameters of all instances.
+	// The allowed values of the namespace policies are limited to the ones allowed by the cluster policy.
+	Restrictions *DBaaSPolicyRestrictions `json:"restrictions,omitempty"`
+
+	// Namespaces allowed to connect to the inventories of all namespaces.
+	// The connection namespaces of the namespace policies are limited to the ones allowed by the cluster policy,
+	// the namespace of an inventory is always allowed to connect to it.
+	Connections *DBaaSConnectionPolicy `json:"connections,omitempty"`
+
+	// The maximum quotas of all namespaces.
+	Quotas *DBaaSPolicyQuotas `json:"quotas,omitempty"`
+
+	// +kubebuilder:validation:Enum=Warn;Enforce
+	// Enforces the policies of all namespaces when set to Enforce.
+	EnforcementMode *PolicyEnforcementMode `json:"enforcementMode,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DBaaSClusterPolicy sets cluster-wide ceilings for the DBaaSPolicy objects of all namespaces and for the inventory policies.
+// When there are several cluster policies, the most restrictive setting applies.
+// +operator-sdk:csv:customresourcedefinitions:displayName="Cluster Provider Account Policy"
+type DBaaSClusterPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DBaaSClusterPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBaaSClusterPolicyList contains a list of DBaaSClusterPolicy objects.
+type DBaaSClusterPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSClusterPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSClusterPolicy{}, &DBaaSClusterPolicyList{})
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"reflect"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -31,6 +34,9 @@ var dbaasconnectionlog = logf.Log.WithName("dbaasconnection-resource")
 
 // SetupWebhookWithManager sets up the webhook with the Manager.
 func (r *DBaaSConnection) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookAPIClient == nil {
+		WebhookAPIClient = mgr.GetClient()
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -43,13 +49,24 @@ var _ webhook.Validator = &DBaaSConnection{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *DBaaSConnection) ValidateCreate() error {
 	dbaasconnectionlog.Info("validate create", "name", r.Name)
-	return r.validateCreateDBaaSConnectionSpec()
+	if err := r.validateCreateDBaaSConnectionSpec(); err != nil {
+		return err
+	}
+	return r.validatePolicyRules()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *DBaaSConnection) ValidateUpdate(old runtime.Object) error {
 	dbaasconnectionlog.Info("validate update", "name", r.Name)
-	return r.validateUpdateDBaaSConnectionSpec(old.(*DBaaSConnection))
+	oldConnection := old.(*DBaaSConnection)
+	if err := r.validateUpdateDBaaSConnectionSpec(oldConnection); err != nil {
+		return err
+	}
+	// the policy rules do not block the updates of the metadata or status, or the updates of a connection being deleted
+	if r.DeletionTimestamp != nil || reflect.DeepEqual(r.Spec, oldConnection.Spec) {
+		return nil
+	}
+	return r.validatePolicyRules()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -68,7 +85,7 @@ func (r *DBaaSConnection) validateCreateDBaaSConnectionSpec() error {
 	if r.Spec.DatabaseServiceRef != nil && r.Spec.DatabaseServiceType != nil {
 		return field.Invalid(field.NewPath("spec").Child("databaseServiceRef"), r.Spec.DatabaseServiceRef, "when using databaseServiceRef, databaseServiceType must not be specified")
 	}
-	return nil
+	return r.validateRotation()
 }
 
 func (r *DBaaSConnection) validateUpdateDBaaSConnectionSpec(old *DBaaSConnection) error {
@@ -88,5 +105,48 @@ func (r *DBaaSConnection) validateUpdateDBaaSConnectionSpec(old *DBaaSConnection
 		return field.Invalid(field.NewPath("spec").Child("databaseServiceType"), r.Spec.DatabaseServiceType, "databaseServiceType is immutable")
 	}
 
+	return r.validateRotation()
+}
+
+func (r *DBaaSConnection) validateRotation() error {
+	if r.Spec.Rotation == nil {
+		return nil
+	}
+	rotationPath := field.NewPath("spec").Child("rotation")
+	if r.Spec.Rotation.Interval != nil && r.Spec.Rotation.Interval.Duration <= 0 {
+		return field.Invalid(rotationPath.Child("interval"), r.Spec.Rotation.Interval.Duration.String(), "interval must be greater than zero")
+	}
+	if r.Spec.Rotation.GracePeriod != nil {
+		if r.Spec.Rotation.GracePeriod.Duration < 0 {
+			return field.Invalid(rotationPath.Child("gracePeriod"), r.Spec.Rotation.GracePeriod.Duration.String(), "gracePeriod must not be negative")
+		}
+		if r.Spec.Rotation.Interval != nil && r.Spec.Rotation.GracePeriod.Duration >= r.Spec.Rotation.Interval.Duration {
+			return field.Invalid(rotationPath.Child("gracePeriod"), r.Spec.Rotation.GracePeriod.Duration.String(), "gracePeriod must be shorter than interval")
+		}
+	}
 	return nil
 }
+
+// validatePolicyRules checks the connection against the rules of the effective policy of its inventory
+func (r *DBaaSConnection) validatePolicyRules() error {
+	namespace := r.Spec.InventoryRef.Namespace
+	if len(namespace) == 0 {
+		namespace = r.Namespace
+	}
+	inventory := &DBaaSInventory{}
+	if err := WebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: r.Spec.InventoryRef.Name, Namespace: namespace}, inventory); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	_, activePolicy, err := getEffectivePolicy(inventory)
+	if err != nil || activePolicy == nil || len(activePolicy.Spec.Rules) == 0 {
+		return err
+	}
+	namespaceLabels, err := getNamespaceLabels(r.Namespace)
+	if err != nil {
+		return err
+	}
+	return CheckPolicyRules(activePolicy, ruleConnectionKind, r, inventory, namespaceLabels).ToAggregate()
+}
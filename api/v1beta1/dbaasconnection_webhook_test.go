@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -114,6 +116,23 @@ var _ = Describe("DBaaSConnection Webhook", func() {
 				},
 				"admission webhook \"vdbaasconnection.kb.io\" denied the request: "+
 					"spec.databaseServiceType: Invalid value: \"test-databaseServiceType\": databaseServiceType is immutable"),
+			Entry("not allow a rotation interval of zero",
+				func(spec *DBaaSConnectionSpec) {
+					spec.Rotation = &CredentialsRotation{
+						Interval: &metav1.Duration{},
+					}
+				},
+				"admission webhook \"vdbaasconnection.kb.io\" denied the request: "+
+					"spec.rotation.interval: Invalid value: \"0s\": interval must be greater than zero"),
+			Entry("not allow a rotation grace period longer than the interval",
+				func(spec *DBaaSConnectionSpec) {
+					spec.Rotation = &CredentialsRotation{
+						Interval:    &metav1.Duration{Duration: time.Hour},
+						GracePeriod: &metav1.Duration{Duration: 2 * time.Hour},
+					}
+				},
+				"admission webhook \"vdbaasconnection.kb.io\" denied the request: "+
+					"spec.rotation.gracePeriod: Invalid value: \"2h0m0s\": gracePeriod must be shorter than interval"),
 		)
 	})
 
@@ -17,18 +17,342 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// immutableProvisioningParameters cannot be changed after the instance is created
+var immutableProvisioningParameters = []ProvisioningParameterType{
+	ProvisioningPlan,
+	ProvisioningCloudProvider,
+	ProvisioningRegions,
+}
+
 // log is for logging in this package.
 var dbaasinstancelog = logf.Log.WithName("dbaasinstance-resource")
 
 // SetupWebhookWithManager sets up the webhook with the Manager.
 func (r *DBaaSInstance) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookAPIClient == nil {
+		WebhookAPIClient = mgr.GetClient()
+	}
+	if err := setupInstanceApprovalWebhook(mgr); err != nil {
+		return err
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
 
-// TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+//+kubebuilder:webhook:path=/mutate-dbaas-redhat-com-v1beta1-dbaasinstance,mutating=true,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinstances,verbs=create,versions=v1beta1,name=mdbaasinstance.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.Defaulter = &DBaaSInstance{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *DBaaSInstance) Default() {
+	dbaasinstancelog.Info("default", "name", r.Name)
+	provider, err := getInstanceProvider(r)
+	if err != nil {
+		dbaasinstancelog.Error(err, "Error reading the DBaaS Provider of the instance", "name", r.Name)
+		return
+	}
+	if provider == nil {
+		return
+	}
+
+	var defaulted []string
+	for _, param := range provisioningParameterOrder(provider) {
+		if _, ok := r.Spec.ProvisioningParameters[param]; ok {
+			continue
+		}
+		data := applicableProvisioningParameterData(r.Spec.ProvisioningParameters, provider, param)
+		if data == nil || len(data.DefaultValue) == 0 {
+			continue
+		}
+		if r.Spec.ProvisioningParameters == nil {
+			r.Spec.ProvisioningParameters = map[ProvisioningParameterType]string{}
+		}
+		r.Spec.ProvisioningParameters[param] = data.DefaultValue
+		defaulted = append(defaulted, string(param))
+	}
+	if len(defaulted) > 0 {
+		sort.Strings(defaulted)
+		if r.Annotations == nil {
+			r.Annotations = map[string]string{}
+		}
+		r.Annotations[DefaultedProvisioningParametersAnnotation] = strings.Join(defaulted, ",")
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasinstance,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinstances,verbs=create;update,versions=v1beta1,name=vdbaasinstance.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DBaaSInstance{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateCreate() error {
+	dbaasinstancelog.Info("validate create", "name", r.Name)
+	return validateInstance(r, nil)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateUpdate(old runtime.Object) error {
+	dbaasinstancelog.Info("validate update", "name", r.Name)
+	oldInst := old.(*DBaaSInstance)
+	// the updates of the metadata or status, such as the finalizers, and the updates of an instance being deleted are not validated
+	if r.DeletionTimestamp != nil || reflect.DeepEqual(r.Spec, oldInst.Spec) {
+		return nil
+	}
+	return validateInstance(r, oldInst)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateDelete() error {
+	dbaasinstancelog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func validateInstance(inst *DBaaSInstance, oldInst *DBaaSInstance) error {
+	var errs field.ErrorList
+	paramsPath := field.NewPath("spec").Child("provisioningParameters")
+	if oldInst != nil {
+		for _, param := range immutableProvisioningParameters {
+			if inst.Spec.ProvisioningParameters[param] != oldInst.Spec.ProvisioningParameters[param] {
+				errs = append(errs, field.Invalid(paramsPath.Key(string(param)), inst.Spec.ProvisioningParameters[param], fmt.Sprintf("%s is immutable", param)))
+			}
+		}
+	}
+
+	changedParams := changedProvisioningParameters(inst, oldInst)
+
+	inventory, err := getInstanceInventory(inst)
+	if err != nil {
+		return err
+	}
+	if inventory == nil {
+		return errs.ToAggregate()
+	}
+	policy, activePolicy, err := getEffectivePolicy(inventory)
+	if err != nil {
+		return err
+	}
+	// the existing instances falling out of the restrictions are reported by the operator
+	errs = append(errs, policy.Restrictions.ValidateProvisioningParameters(changedParams, paramsPath)...)
+	if policy.Quotas != nil {
+		usage, err := getPolicyUsage(inst, inventory.Namespace)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, policy.Quotas.ValidateInstance(inst, oldInst, usage)...)
+	}
+	if activePolicy != nil && len(activePolicy.Spec.Rules) > 0 {
+		namespaceLabels, err := getNamespaceLabels(inst.Namespace)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, CheckPolicyRules(activePolicy, ruleInstanceKind, inst, inventory, namespaceLabels)...)
+	}
+
+	provider, err := getInventoryProvider(inventory)
+	if err != nil {
+		return err
+	}
+	if provider != nil {
+		errs = append(errs, validateProvisioningParameters(inst.Spec.ProvisioningParameters, changedParams, provider, paramsPath)...)
+	}
+	return errs.ToAggregate()
+}
+
+// changedProvisioningParameters returns the provisioning parameters set or changed by an update, or all the parameters on creation
+func changedProvisioningParameters(inst *DBaaSInstance, oldInst *DBaaSInstance) map[ProvisioningParameterType]string {
+	if oldInst == nil {
+		return inst.Spec.ProvisioningParameters
+	}
+	changed := map[ProvisioningParameterType]string{}
+	for param, value := range inst.Spec.ProvisioningParameters {
+		if oldValue, ok := oldInst.Spec.ProvisioningParameters[param]; !ok || value != oldValue {
+			changed[param] = value
+		}
+	}
+	return changed
+}
+
+// getInstanceProvider returns the provider of the instance inventory, or nil if the inventory or provider does not exist yet
+func getInstanceProvider(inst *DBaaSInstance) (*DBaaSProvider, error) {
+	inventory, err := getInstanceInventory(inst)
+	if err != nil || inventory == nil {
+		return nil, err
+	}
+	return getInventoryProvider(inventory)
+}
+
+// getPolicyUsage returns the usage of the quotas of the namespace by the instances other than inst.
+// The usage is read from the cache, so the instances created concurrently are not counted.
+func getPolicyUsage(inst *DBaaSInstance, namespace string) (*DBaaSPolicyUsage, error) {
+	instanceList := &DBaaSInstanceList{}
+	if err := WebhookAPIClient.List(context.TODO(), instanceList, client.MatchingFields{InstanceInventoryNamespaceIndex: namespace}); err != nil {
+		return nil, err
+	}
+	instances := make([]DBaaSInstance, 0, len(instanceList.Items))
+	for i := range instanceList.Items {
+		if instanceList.Items[i].Name != inst.Name || instanceList.Items[i].Namespace != inst.Namespace {
+			instances = append(instances, instanceList.Items[i])
+		}
+	}
+	return GetPolicyUsage(namespace, instances), nil
+}
+
+// getInstanceInventory returns the inventory of the instance, or nil if it does not exist yet
+func getInstanceInventory(inst *DBaaSInstance) (*DBaaSInventory, error) {
+	inventory := &DBaaSInventory{}
+	if err := WebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: inst.Spec.InventoryRef.Name, Namespace: inst.Spec.InventoryRef.Namespace}, inventory); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return inventory, nil
+}
+
+// getInventoryProvider returns the provider of the inventory, or nil if it does not exist yet
+func getInventoryProvider(inventory *DBaaSInventory) (*DBaaSProvider, error) {
+	provider := &DBaaSProvider{}
+	if err := WebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: inventory.Spec.ProviderRef.Name}, provider); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return provider, nil
+}
+
+// validateProvisioningParameters checks the checked provisioning parameters against the ones supported by the provider,
+// the conditions of the parameter options are evaluated with all the parameters
+func validateProvisioningParameters(params, checked map[ProvisioningParameterType]string, provider *DBaaSProvider, paramsPath *field.Path) field.ErrorList {
+	if len(provider.Spec.ProvisioningParameters) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(checked))
+	for key := range checked {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+
+	var errs field.ErrorList
+	for _, key := range keys {
+		param := ProvisioningParameterType(key)
+		value := params[param]
+		if _, ok := provider.Spec.ProvisioningParameters[param]; !ok {
+			errs = append(errs, field.Invalid(paramsPath.Key(key), value, fmt.Sprintf("provisioning parameter is not supported by provider %s", provider.Name)))
+			continue
+		}
+		data := applicableProvisioningParameterData(params, provider, param)
+		if data == nil || len(data.Options) == 0 {
+			continue
+		}
+		if !isProvisioningParameterOption(data.Options, value) {
+			msg := fmt.Sprintf("value is not a supported option, supported values: %s", strings.Join(optionValues(data.Options), ", "))
+			errs = append(errs, field.Invalid(paramsPath.Key(key), value, msg))
+		}
+	}
+	return errs
+}
+
+// provisioningParameterOrder returns the provider's provisioning parameters, with every parameter after its dependencies
+func provisioningParameterOrder(provider *DBaaSProvider) []ProvisioningParameterType {
+	keys := make([]string, 0, len(provider.Spec.ProvisioningParameters))
+	for key := range provider.Spec.ProvisioningParameters {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+
+	order := make([]ProvisioningParameterType, 0, len(keys))
+	visited := map[ProvisioningParameterType]bool{}
+	var visit func(param ProvisioningParameterType)
+	visit = func(param ProvisioningParameterType) {
+		if visited[param] {
+			return
+		}
+		visited[param] = true
+		parameter, ok := provider.Spec.ProvisioningParameters[param]
+		if !ok {
+			return
+		}
+		for _, data := range parameter.ConditionalData {
+			for _, dependency := range data.Dependencies {
+				visit(dependency.Field)
+			}
+		}
+		order = append(order, param)
+	}
+	for _, key := range keys {
+		visit(ProvisioningParameterType(key))
+	}
+	return order
+}
+
+// applicableProvisioningParameterData returns the conditional data of the parameter with all dependencies matched by the
+// provisioning parameters, or nil if there is none
+func applicableProvisioningParameterData(params map[ProvisioningParameterType]string, provider *DBaaSProvider, param ProvisioningParameterType) *ConditionalProvisioningParameterData {
+	return findProvisioningParameterData(params, provider, param, map[ProvisioningParameterType]bool{})
+}
+
+func findProvisioningParameterData(params map[ProvisioningParameterType]string, provider *DBaaSProvider, param ProvisioningParameterType,
+	visited map[ProvisioningParameterType]bool) *ConditionalProvisioningParameterData {
+	if visited[param] {
+		return nil
+	}
+	visited[param] = true
+	defer delete(visited, param)
+
+	conditionalData := provider.Spec.ProvisioningParameters[param].ConditionalData
+	for i := range conditionalData {
+		matched := true
+		for _, dependency := range conditionalData[i].Dependencies {
+			value, ok := params[dependency.Field]
+			if !ok {
+				// Fall back to the default value of the dependency field
+				if data := findProvisioningParameterData(params, provider, dependency.Field, visited); data != nil {
+					value = data.DefaultValue
+				}
+			}
+			if value != dependency.Value {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return &conditionalData[i]
+		}
+	}
+	return nil
+}
+
+func isProvisioningParameterOption(options []Option, value string) bool {
+	for _, option := range options {
+		if option.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func optionValues(options []Option) []string {
+	values := make([]string, 0, len(options))
+	for _, option := range options {
+		values = append(values, option.Value)
+	}
+	return values
+}
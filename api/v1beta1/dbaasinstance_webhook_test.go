@@ -0,0 +1,457 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testInstanceProviderName  = "test-instance-provider"
+	testInstanceInventoryName = "test-instance-inventory"
+	testInstanceSecretName    = "test-instance-secret"
+)
+
+var (
+	testInstanceProvider = DBaaSProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testInstanceProviderName,
+		},
+		Spec: DBaaSProviderSpec{
+			Provider: DatabaseProviderInfo{
+				Name: testInstanceProviderName,
+			},
+			InventoryKind:  testInventoryKind,
+			ConnectionKind: testConnectionKind,
+			InstanceKind:   testInstanceKind,
+			ProvisioningParameters: map[ProvisioningParameterType]ProvisioningParameter{
+				ProvisioningName: {
+					DisplayName: "Cluster name",
+				},
+				ProvisioningPlan: {
+					DisplayName: "Hosting plan",
+					ConditionalData: []ConditionalProvisioningParameterData{
+						{
+							DefaultValue: ProvisioningPlanServerless,
+							Options: []Option{
+								{Value: ProvisioningPlanServerless, DisplayValue: "Serverless"},
+								{Value: ProvisioningPlanDedicated, DisplayValue: "Dedicated"},
+							},
+						},
+					},
+				},
+				ProvisioningCloudProvider: {
+					DisplayName: "Cloud provider",
+					ConditionalData: []ConditionalProvisioningParameterData{
+						{
+							DefaultValue: "AWS",
+							Dependencies: []FieldDependency{
+								{Field: ProvisioningPlan, Value: ProvisioningPlanServerless},
+							},
+							Options: []Option{
+								{Value: "AWS", DisplayValue: "Amazon Web Services"},
+							},
+						},
+						{
+							DefaultValue: "AWS",
+							Dependencies: []FieldDependency{
+								{Field: ProvisioningPlan, Value: ProvisioningPlanDedicated},
+							},
+							Options: []Option{
+								{Value: "AWS", DisplayValue: "Amazon Web Services"},
+								{Value: "GCP", DisplayValue: "Google Cloud Platform"},
+							},
+						},
+					},
+				},
+				ProvisioningMachineType: {
+					DisplayName: "Compute",
+					ConditionalData: []ConditionalProvisioningParameterData{
+						{
+							DefaultValue: "m5.large",
+							Dependencies: []FieldDependency{
+								{Field: ProvisioningPlan, Value: ProvisioningPlanDedicated},
+								{Field: ProvisioningCloudProvider, Value: "AWS"},
+							},
+							Options: []Option{
+								{Value: "m5.large", DisplayValue: "2 vCPU, 8 GiB RAM"},
+								{Value: "m5.xlarge", DisplayValue: "4 vCPU, 16 GiB RAM"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	testInstanceSecret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testInstanceSecretName,
+			Namespace: testNamespace,
+		},
+	}
+	testInstanceInventory = DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testInstanceInventoryName,
+			Namespace: testNamespace,
+		},
+		Spec: DBaaSOperatorInventorySpec{
+			ProviderRef: NamespacedName{
+				Name: testInstanceProviderName,
+			},
+			DBaaSInventorySpec: DBaaSInventorySpec{
+				CredentialsRef: &LocalObjectReference{
+					Name: testInstanceSecretName,
+				},
+			},
+		},
+	}
+	testDBaaSInstance = DBaaSInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-instance",
+			Namespace: testNamespace,
+		},
+		Spec: DBaaSInstanceSpec{
+			InventoryRef: NamespacedName{
+				Name:      testInstanceInventoryName,
+				Namespace: testNamespace,
+			},
+			ProvisioningParameters: map[ProvisioningParameterType]string{
+				ProvisioningName:          "test-cluster",
+				ProvisioningPlan:          ProvisioningPlanDedicated,
+				ProvisioningCloudProvider: "GCP",
+			},
+		},
+	}
+)
+
+var _ = Describe("DBaaSInstance Webhook", func() {
+	BeforeEach(assertResourceCreation(&testInstanceProvider))
+	BeforeEach(assertResourceCreation(&testInstanceSecret))
+	BeforeEach(assertResourceCreation(&testInstanceInventory))
+	AfterEach(assertResourceDeletion(&testInstanceInventory))
+	AfterEach(assertResourceDeletion(&testInstanceSecret))
+	AfterEach(assertResourceDeletion(&testInstanceProvider))
+
+	DescribeTable("checking DBaaSInstance creation",
+		func(params map[ProvisioningParameterType]string, expectedErr string) {
+			inst := testDBaaSInstance.DeepCopy()
+			inst.Name = "test-instance-create"
+			inst.Spec.ProvisioningParameters = params
+			err := k8sClient.Create(ctx, inst)
+			if len(expectedErr) == 0 {
+				Expect(err).ShouldNot(HaveOccurred())
+				assertResourceDeletion(inst)()
+			} else {
+				Expect(err).Should(MatchError(expectedErr))
+			}
+		},
+		Entry("allow supported options",
+			map[ProvisioningParameterType]string{
+				ProvisioningName:          "test-cluster",
+				ProvisioningPlan:          ProvisioningPlanDedicated,
+				ProvisioningCloudProvider: "AWS",
+				ProvisioningMachineType:   "m5.xlarge",
+			}, ""),
+		Entry("use the default values of dependency fields",
+			map[ProvisioningParameterType]string{
+				ProvisioningCloudProvider: "AWS",
+			}, ""),
+		Entry("not allow unsupported parameters",
+			map[ProvisioningParameterType]string{
+				ProvisioningNodes: "3",
+			},
+			"admission webhook \"vdbaasinstance.kb.io\" denied the request: "+
+				"spec.provisioningParameters[nodes]: Invalid value: \"3\": provisioning parameter is not supported by provider test-instance-provider"),
+		Entry("not allow options of other dependencies",
+			map[ProvisioningParameterType]string{
+				ProvisioningPlan:          ProvisioningPlanServerless,
+				ProvisioningCloudProvider: "GCP",
+			},
+			"admission webhook \"vdbaasinstance.kb.io\" denied the request: "+
+				"spec.provisioningParameters[cloudProvider]: Invalid value: \"GCP\": value is not a supported option, supported values: AWS"),
+	)
+
+	Context("after creating DBaaSInstance without optional parameters", func() {
+		inst := testDBaaSInstance.DeepCopy()
+		inst.Name = "test-instance-defaults"
+		inst.Spec.ProvisioningParameters = map[ProvisioningParameterType]string{
+			ProvisioningName: "test-cluster",
+			ProvisioningPlan: ProvisioningPlanDedicated,
+		}
+		BeforeEach(assertResourceCreation(inst))
+		AfterEach(assertResourceDeletion(inst))
+
+		It("should fill the missing parameters with the provider defaults", func() {
+			createdInst := &DBaaSInstance{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(inst), createdInst)).Should(Succeed())
+			Expect(createdInst.Spec.ProvisioningParameters).Should(Equal(map[ProvisioningParameterType]string{
+				ProvisioningName:          "test-cluster",
+				ProvisioningPlan:          ProvisioningPlanDedicated,
+				ProvisioningCloudProvider: "AWS",
+				ProvisioningMachineType:   "m5.large",
+			}))
+			Expect(createdInst.Annotations).Should(HaveKeyWithValue(DefaultedProvisioningParametersAnnotation, "cloudProvider,machineType"))
+		})
+	})
+
+	Context("with policy restrictions on the inventory", func() {
+		BeforeEach(func() {
+			inv := &DBaaSInventory{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&testInstanceInventory), inv)).Should(Succeed())
+			maxNodes := int32(3)
+			inv.Spec.Policy = &DBaaSInventoryPolicy{
+				Restrictions: &DBaaSPolicyRestrictions{
+					Plans:          []string{ProvisioningPlanDedicated},
+					CloudProviders: []string{"AWS"},
+					MaxNodes:       &maxNodes,
+				},
+			}
+			Expect(k8sClient.Update(ctx, inv)).Should(Succeed())
+		})
+
+		DescribeTable("checking DBaaSInstance creation",
+			func(params map[ProvisioningParameterType]string, expectedErr string) {
+				inst := testDBaaSInstance.DeepCopy()
+				inst.Name = "test-instance-restricted"
+				inst.Spec.ProvisioningParameters = params
+				err := k8sClient.Create(ctx, inst)
+				if len(expectedErr) == 0 {
+					Expect(err).ShouldNot(HaveOccurred())
+					assertResourceDeletion(inst)()
+				} else {
+					Expect(err).Should(MatchError(expectedErr))
+				}
+			},
+			Entry("allow parameters within the restrictions",
+				map[ProvisioningParameterType]string{
+					ProvisioningPlan:          ProvisioningPlanDedicated,
+					ProvisioningCloudProvider: "AWS",
+				}, ""),
+			Entry("not allow a plan outside the restrictions",
+				map[ProvisioningParameterType]string{
+					ProvisioningPlan: ProvisioningPlanServerless,
+				},
+				"admission webhook \"vdbaasinstance.kb.io\" denied the request: "+
+					"spec.provisioningParameters[plan]: Forbidden: value SERVERLESS is not allowed by policy, allowed values: DEDICATED"),
+		)
+
+		It("should only check the changed parameters of an existing instance", func() {
+			inst := testDBaaSInstance.DeepCopy()
+			inst.Name = "test-instance-restricted-update"
+			Expect(k8sClient.Create(ctx, inst)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.provisioningParameters[cloudProvider]: Forbidden: value GCP is not allowed by policy, allowed values: AWS"))
+
+			inv := &DBaaSInventory{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&testInstanceInventory), inv)).Should(Succeed())
+			inv.Spec.Policy = nil
+			Expect(k8sClient.Update(ctx, inv)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, inst)).Should(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&testInstanceInventory), inv)).Should(Succeed())
+			inv.Spec.Policy = &DBaaSInventoryPolicy{
+				Restrictions: &DBaaSPolicyRestrictions{
+					CloudProviders: []string{"AWS"},
+				},
+			}
+			Expect(k8sClient.Update(ctx, inv)).Should(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(inst), inst)).Should(Succeed())
+			inst.Spec.ProvisioningParameters[ProvisioningName] = "updated-cluster"
+			Expect(k8sClient.Update(ctx, inst)).Should(Succeed())
+			assertResourceDeletion(inst)()
+		})
+	})
+
+	Context("with an active policy setting rules", func() {
+		policy := &DBaaSPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-rules-policy",
+				Namespace: testNamespace,
+			},
+			Spec: DBaaSPolicySpec{
+				Rules: []DBaaSPolicyRule{
+					{
+						Name:       "team-prefix",
+						Kinds:      []string{"DBaaSInstance"},
+						Expression: "namespaceLabels['kubernetes.io/metadata.name'] != '" + testNamespace + "' || object.metadata.name.startsWith('team-')",
+						Message:    "instance names must start with team-",
+					},
+					{
+						Name:       "connections-only",
+						Kinds:      []string{"DBaaSConnection"},
+						Expression: "false",
+						Message:    "only checked for connections",
+					},
+				},
+			},
+		}
+		BeforeEach(assertResourceCreation(policy))
+		BeforeEach(func() {
+			apimeta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+				Type:   DBaaSPolicyReadyType,
+				Status: metav1.ConditionTrue,
+				Reason: Ready,
+			})
+			Expect(k8sClient.Status().Update(ctx, policy)).Should(Succeed())
+		})
+		AfterEach(assertResourceDeletion(policy))
+
+		DescribeTable("checking DBaaSInstance creation",
+			func(name string, expectedErr string) {
+				inst := testDBaaSInstance.DeepCopy()
+				inst.Name = name
+				err := k8sClient.Create(ctx, inst)
+				if len(expectedErr) == 0 {
+					Expect(err).ShouldNot(HaveOccurred())
+					assertResourceDeletion(inst)()
+				} else {
+					Expect(err).Should(MatchError(expectedErr))
+				}
+			},
+			Entry("allow an instance matching the rules", "team-instance", ""),
+			Entry("not allow an instance denied by a rule", "test-instance-rules",
+				"admission webhook \"vdbaasinstance.kb.io\" denied the request: rules[team-prefix]: Forbidden: instance names must start with team-"),
+		)
+
+		It("should allow metadata-only updates of an existing instance denied by a rule", func() {
+			inst := testDBaaSInstance.DeepCopy()
+			inst.Name = "test-instance-rules-existing"
+			apimeta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+				Type:   DBaaSPolicyReadyType,
+				Status: metav1.ConditionFalse,
+				Reason: DBaaSPolicyNotReady,
+			})
+			Expect(k8sClient.Status().Update(ctx, policy)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, inst)).Should(Succeed())
+
+			apimeta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+				Type:   DBaaSPolicyReadyType,
+				Status: metav1.ConditionTrue,
+				Reason: Ready,
+			})
+			Expect(k8sClient.Status().Update(ctx, policy)).Should(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(inst), inst)).Should(Succeed())
+			inst.Labels = map[string]string{"test": "label"}
+			Expect(k8sClient.Update(ctx, inst)).Should(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(inst), inst)).Should(Succeed())
+			inst.Spec.ProvisioningParameters[ProvisioningName] = "updated-cluster"
+			Expect(k8sClient.Update(ctx, inst)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"rules[team-prefix]: Forbidden: instance names must start with team-"))
+			assertResourceDeletion(inst)()
+		})
+	})
+
+	Context("with an active policy setting quotas", func() {
+		maxInstances := int32(1)
+		policy := &DBaaSPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-quota-policy",
+				Namespace: testNamespace,
+			},
+			Spec: DBaaSPolicySpec{
+				Quotas: &DBaaSPolicyQuotas{
+					MaxInstancesPerInventory: &maxInstances,
+				},
+			},
+		}
+		inst := testDBaaSInstance.DeepCopy()
+		inst.Name = "test-instance-quota"
+		BeforeEach(assertResourceCreation(policy))
+		BeforeEach(func() {
+			apimeta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+				Type:   DBaaSPolicyReadyType,
+				Status: metav1.ConditionTrue,
+				Reason: Ready,
+			})
+			Expect(k8sClient.Status().Update(ctx, policy)).Should(Succeed())
+		})
+		BeforeEach(assertResourceCreation(inst))
+		AfterEach(assertResourceDeletion(inst))
+		AfterEach(assertResourceDeletion(policy))
+
+		It("should not allow creating an instance once the quota is exhausted", func() {
+			otherInst := testDBaaSInstance.DeepCopy()
+			otherInst.Name = "test-instance-quota-exhausted"
+			Expect(k8sClient.Create(ctx, otherInst)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.inventoryRef: Forbidden: quota of 1 instances for inventory test-instance-inventory is exhausted"))
+		})
+
+		It("should allow updating the instance", func() {
+			updatedInst := &DBaaSInstance{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(inst), updatedInst)).Should(Succeed())
+			updatedInst.Spec.ProvisioningParameters[ProvisioningName] = "updated-cluster"
+			Expect(k8sClient.Update(ctx, updatedInst)).Should(Succeed())
+		})
+	})
+
+	Context("after creating DBaaSInstance", func() {
+		inst := testDBaaSInstance.DeepCopy()
+		BeforeEach(assertResourceCreation(inst))
+		AfterEach(assertResourceDeletion(inst))
+
+		DescribeTable("checking DBaaSInstance updates",
+			func(param ProvisioningParameterType, value string, expectedErr string) {
+				updatedInst := &DBaaSInstance{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(inst), updatedInst)).Should(Succeed())
+				updatedInst.Spec.ProvisioningParameters[param] = value
+				err := k8sClient.Update(ctx, updatedInst)
+				if len(expectedErr) == 0 {
+					Expect(err).ShouldNot(HaveOccurred())
+				} else {
+					Expect(err).Should(MatchError(expectedErr))
+				}
+			},
+			Entry("allow updating mutable parameters", ProvisioningName, "updated-cluster", ""),
+			Entry("not allow updating plan", ProvisioningPlan, ProvisioningPlanServerless,
+				"admission webhook \"vdbaasinstance.kb.io\" denied the request: "+
+					"spec.provisioningParameters[plan]: Invalid value: \"SERVERLESS\": plan is immutable"),
+			Entry("not allow updating cloudProvider", ProvisioningCloudProvider, "AWS",
+				"admission webhook \"vdbaasinstance.kb.io\" denied the request: "+
+					"spec.provisioningParameters[cloudProvider]: Invalid value: \"AWS\": cloudProvider is immutable"),
+		)
+
+		It("should allow metadata updates and finalizer removal when the provider options changed", func() {
+			finalizedInst := testDBaaSInstance.DeepCopy()
+			finalizedInst.Name = "test-instance-finalizer"
+			finalizedInst.Finalizers = []string{"dbaas.redhat.com/test-finalizer"}
+			Expect(k8sClient.Create(ctx, finalizedInst)).Should(Succeed())
+
+			provider := &DBaaSProvider{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&testInstanceProvider), provider)).Should(Succeed())
+			delete(provider.Spec.ProvisioningParameters, ProvisioningName)
+			Expect(k8sClient.Update(ctx, provider)).Should(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(finalizedInst), finalizedInst)).Should(Succeed())
+			finalizedInst.Labels = map[string]string{"test": "label"}
+			Expect(k8sClient.Update(ctx, finalizedInst)).Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, finalizedInst)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(finalizedInst), finalizedInst)).Should(Succeed())
+			finalizedInst.Finalizers = nil
+			Expect(k8sClient.Update(ctx, finalizedInst)).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DBaaSInstanceApprovalSpec defines the desired state of a DBaaSInstanceApproval object.
+type DBaaSInstanceApprovalSpec struct {
+	// The name of the approved DBaaSInstance object, in the namespace of the approval.
+	InstanceName string `json:"instanceName"`
+}
+
+// DBaaSInstanceApprovalInfo records the approval of a DBaaSInstance.
+type DBaaSInstanceApprovalInfo struct {
+	// The user who approved the instance.
+	Approver string `json:"approver"`
+
+	// The time the instance was approved.
+	ApprovalTime metav1.Time `json:"approvalTime"`
+}
+
+// DBaaSInstanceApprovalStatus defines the observed state of a DBaaSInstanceApproval object.
+// It is set by the DBaaS Operator when the approval is created.
+type DBaaSInstanceApprovalStatus struct {
+	DBaaSInstanceApprovalInfo `json:",inline"`
+
+	// The UID of the approved DBaaSInstance object. A DBaaSInstance recreated with the same name is not approved.
+	InstanceUID types.UID `json:"instanceUID,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Instance",type=string,JSONPath=`.spec.instanceName`
+//+kubebuilder:printcolumn:name="Approver",type=string,JSONPath=`.status.approver`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DBaaSInstanceApproval approves the provisioning of a DBaaSInstance, when the inventory policy requires approvals.
+// The approval is immutable, and can only be created by a user allowed to approve DBaaSInstance objects in the namespace of the inventory.
+// The approval is owned by the approved instance, and is deleted with it.
+// +operator-sdk:csv:customresourcedefinitions:displayName="DBaaSInstanceApproval"
+type DBaaSInstanceApproval struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSInstanceApprovalSpec   `json:"spec,omitempty"`
+	Status DBaaSInstanceApprovalStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBaaSInstanceApprovalList contains a list of DBaaSInstanceApprovals.
+type DBaaSInstanceApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSInstanceApproval `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSInstanceApproval{}, &DBaaSInstanceApprovalList{})
+}
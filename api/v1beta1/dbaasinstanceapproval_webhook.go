@@ -0,0 +1,249 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// ApproveVerb is the verb checked on dbaasinstances in the namespace of the inventory to approve an instance
+	ApproveVerb = "approve"
+
+	instanceApprovalPath = "/mutate-dbaas-redhat-com-v1beta1-dbaasinstance-approval"
+)
+
+// log is for logging in this package.
+var dbaasinstanceapprovallog = logf.Log.WithName("dbaasinstanceapproval-resource")
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *DBaaSInstanceApproval) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookAPIClient == nil {
+		WebhookAPIClient = mgr.GetClient()
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(&instanceApprovalWebhook{}).
+		WithValidator(&instanceApprovalWebhook{}).
+		Complete()
+}
+
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+//+kubebuilder:webhook:path=/mutate-dbaas-redhat-com-v1beta1-dbaasinstanceapproval,mutating=true,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinstanceapprovals,verbs=create,versions=v1beta1,name=mdbaasinstanceapproval.kb.io,admissionReviewVersions=v1beta1
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasinstanceapproval,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinstanceapprovals,verbs=create;update,versions=v1beta1,name=vdbaasinstanceapproval.kb.io,admissionReviewVersions=v1beta1
+
+// instanceApprovalWebhook records the approver of the DBaaSInstanceApprovals, and checks that they are allowed to approve the instance
+type instanceApprovalWebhook struct{}
+
+var _ webhook.CustomDefaulter = &instanceApprovalWebhook{}
+var _ webhook.CustomValidator = &instanceApprovalWebhook{}
+
+// Default implements webhook.CustomDefaulter, the approver and approval time are set from the admission request.
+// The approval is bound to the UID of the approved instance, and owned by the instance.
+func (w *instanceApprovalWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	approval := obj.(*DBaaSInstanceApproval)
+	dbaasinstanceapprovallog.Info("default", "name", approval.Name)
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	approval.Status.Approver = req.UserInfo.Username
+	approval.Status.ApprovalTime = metav1.Now()
+	approval.Status.InstanceUID = ""
+
+	inst := &DBaaSInstance{}
+	if err := WebhookAPIClient.Get(ctx, types.NamespacedName{Name: approval.Spec.InstanceName, Namespace: approval.Namespace}, inst); err != nil {
+		if errors.IsNotFound(err) {
+			// the approval of a missing instance is denied by the validation
+			return nil
+		}
+		return err
+	}
+	approval.Status.InstanceUID = inst.UID
+	for _, ref := range approval.OwnerReferences {
+		if ref.UID == inst.UID {
+			return nil
+		}
+	}
+	approval.OwnerReferences = append(approval.OwnerReferences, metav1.OwnerReference{
+		APIVersion: GroupVersion.String(),
+		Kind:       "DBaaSInstance",
+		Name:       inst.Name,
+		UID:        inst.UID,
+	})
+	return nil
+}
+
+// ValidateCreate implements webhook.CustomValidator, the approver must be allowed to approve the instance
+func (w *instanceApprovalWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	approval := obj.(*DBaaSInstanceApproval)
+	dbaasinstanceapprovallog.Info("validate create", "name", approval.Name)
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if approval.Status.Approver != req.UserInfo.Username {
+		return field.Forbidden(field.NewPath("status").Child("approver"), "approver must be the user creating the approval")
+	}
+	inst := &DBaaSInstance{}
+	if err := WebhookAPIClient.Get(ctx, types.NamespacedName{Name: approval.Spec.InstanceName, Namespace: approval.Namespace}, inst); err != nil {
+		if errors.IsNotFound(err) {
+			return field.NotFound(field.NewPath("spec").Child("instanceName"), approval.Spec.InstanceName)
+		}
+		return err
+	}
+	if approval.Status.InstanceUID != inst.UID {
+		return field.Forbidden(field.NewPath("status").Child("instanceUID"), "instance UID must be the UID of the approved instance")
+	}
+	return checkApprover(ctx, req.UserInfo, inst)
+}
+
+// ValidateUpdate implements webhook.CustomValidator, an approval is immutable
+func (w *instanceApprovalWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) error {
+	approval := newObj.(*DBaaSInstanceApproval)
+	dbaasinstanceapprovallog.Info("validate update", "name", approval.Name)
+	oldApproval := oldObj.(*DBaaSInstanceApproval)
+	if !reflect.DeepEqual(approval.Spec, oldApproval.Spec) || !reflect.DeepEqual(approval.Status, oldApproval.Status) {
+		return field.Forbidden(field.NewPath("spec"), "approval is immutable")
+	}
+	return nil
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (w *instanceApprovalWebhook) ValidateDelete(_ context.Context, obj runtime.Object) error {
+	dbaasinstanceapprovallog.Info("validate delete", "name", obj.(*DBaaSInstanceApproval).Name)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/mutate-dbaas-redhat-com-v1beta1-dbaasinstance-approval,mutating=true,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinstances,verbs=create;update,versions=v1beta1,name=mapproveddbaasinstance.kb.io,admissionReviewVersions=v1beta1
+
+// instanceApprovalAnnotator checks the user approving a DBaaSInstance with the approval annotation, and records the approver
+// and approval time in annotations. The recorded approval cannot be changed by the users.
+type instanceApprovalAnnotator struct {
+	decoder *admission.Decoder
+}
+
+// Handle implements admission.Handler
+func (a *instanceApprovalAnnotator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	inst := &DBaaSInstance{}
+	if err := a.decoder.Decode(req, inst); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	var oldAnnotations map[string]string
+	if req.Operation == admissionv1.Update {
+		oldInst := &DBaaSInstance{}
+		if err := a.decoder.DecodeRaw(req.OldObject, oldInst); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		oldAnnotations = oldInst.Annotations
+	}
+
+	approvedBy, approvedAt := oldAnnotations[InstanceApprovedByAnnotation], oldAnnotations[InstanceApprovedAtAnnotation]
+	if inst.Annotations[InstanceApprovedAnnotation] != "true" {
+		approvedBy, approvedAt = "", ""
+	} else if oldAnnotations[InstanceApprovedAnnotation] != "true" {
+		dbaasinstanceapprovallog.Info("approve", "name", inst.Name, "user", req.UserInfo.Username)
+		if err := checkApprover(ctx, req.UserInfo, inst); err != nil {
+			return admission.Denied(err.Error())
+		}
+		approvedBy, approvedAt = req.UserInfo.Username, time.Now().UTC().Format(time.RFC3339)
+	}
+	if inst.Annotations[InstanceApprovedByAnnotation] == approvedBy && inst.Annotations[InstanceApprovedAtAnnotation] == approvedAt {
+		return admission.Allowed("")
+	}
+
+	setAnnotation(inst, InstanceApprovedByAnnotation, approvedBy)
+	setAnnotation(inst, InstanceApprovedAtAnnotation, approvedAt)
+	marshaled, err := json.Marshal(inst)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// setAnnotation sets the annotation, or removes it when the value is empty
+func setAnnotation(inst *DBaaSInstance, key, value string) {
+	if len(value) == 0 {
+		delete(inst.Annotations, key)
+		return
+	}
+	if inst.Annotations == nil {
+		inst.Annotations = map[string]string{}
+	}
+	inst.Annotations[key] = value
+}
+
+// checkApprover checks with a SubjectAccessReview that the user can approve DBaaSInstance objects in the namespace of
+// the instance inventory
+func checkApprover(ctx context.Context, userInfo authenticationv1.UserInfo, inst *DBaaSInstance) error {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range userInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	namespace := GetInstanceInventoryNamespace(inst)
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			Groups: userInfo.Groups,
+			UID:    userInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      ApproveVerb,
+				Group:     GroupVersion.Group,
+				Resource:  "dbaasinstances",
+				Name:      inst.Name,
+			},
+		},
+	}
+	if err := WebhookAPIClient.Create(ctx, sar); err != nil {
+		return err
+	}
+	if !sar.Status.Allowed {
+		return fmt.Errorf("user %s is not allowed to approve the instances of the inventories in namespace %s", userInfo.Username, namespace)
+	}
+	return nil
+}
+
+// setupInstanceApprovalWebhook registers the webhook handling the approval annotation of the DBaaSInstances
+func setupInstanceApprovalWebhook(mgr ctrl.Manager) error {
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	mgr.GetWebhookServer().Register(instanceApprovalPath, &webhook.Admission{Handler: &instanceApprovalAnnotator{decoder: decoder}})
+	return nil
+}
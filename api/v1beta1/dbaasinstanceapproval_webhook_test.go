@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("DBaaSInstanceApproval Webhook", func() {
+	inst := &DBaaSInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-approval-instance",
+			Namespace: testNamespace,
+		},
+		Spec: DBaaSInstanceSpec{
+			InventoryRef: NamespacedName{
+				Name:      "test-approval-inventory",
+				Namespace: testNamespace,
+			},
+		},
+	}
+	BeforeEach(assertResourceCreation(inst))
+	AfterEach(assertResourceDeletion(inst))
+
+	Context("after creating DBaaSInstanceApproval", func() {
+		approval := &DBaaSInstanceApproval{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-approval",
+				Namespace: testNamespace,
+			},
+			Spec: DBaaSInstanceApprovalSpec{
+				InstanceName: inst.Name,
+			},
+		}
+		BeforeEach(assertResourceCreation(approval))
+		AfterEach(assertResourceDeletion(approval))
+
+		It("should record the approver and approval time", func() {
+			createdApproval := &DBaaSInstanceApproval{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(approval), createdApproval)).Should(Succeed())
+			Expect(createdApproval.Status.Approver).ShouldNot(BeEmpty())
+			Expect(createdApproval.Status.ApprovalTime.IsZero()).Should(BeFalse())
+		})
+
+		It("should bind the approval to the instance", func() {
+			approvedInst := &DBaaSInstance{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(inst), approvedInst)).Should(Succeed())
+			createdApproval := &DBaaSInstanceApproval{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(approval), createdApproval)).Should(Succeed())
+			Expect(createdApproval.Status.InstanceUID).Should(Equal(approvedInst.UID))
+			Expect(createdApproval.OwnerReferences).Should(ConsistOf(metav1.OwnerReference{
+				APIVersion: GroupVersion.String(),
+				Kind:       "DBaaSInstance",
+				Name:       approvedInst.Name,
+				UID:        approvedInst.UID,
+			}))
+		})
+
+		It("should not allow updating the approval", func() {
+			updatedApproval := &DBaaSInstanceApproval{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(approval), updatedApproval)).Should(Succeed())
+			updatedApproval.Status.Approver = "someone-else"
+			Expect(k8sClient.Update(ctx, updatedApproval)).Should(MatchError("admission webhook \"vdbaasinstanceapproval.kb.io\" denied the request: " +
+				"spec: Forbidden: approval is immutable"))
+		})
+	})
+
+	It("should not allow approving a missing instance", func() {
+		approval := &DBaaSInstanceApproval{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-approval-missing",
+				Namespace: testNamespace,
+			},
+			Spec: DBaaSInstanceApprovalSpec{
+				InstanceName: "test-missing-instance",
+			},
+		}
+		Expect(k8sClient.Create(ctx, approval)).Should(MatchError("admission webhook \"vdbaasinstanceapproval.kb.io\" denied the request: " +
+			"spec.instanceName: Not found: \"test-missing-instance\""))
+	})
+
+	It("should record the approver of the approval annotation", func() {
+		approvedInst := &DBaaSInstance{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(inst), approvedInst)).Should(Succeed())
+		approvedInst.Annotations = map[string]string{InstanceApprovedAnnotation: "true"}
+		Expect(k8sClient.Update(ctx, approvedInst)).Should(Succeed())
+		Expect(approvedInst.Annotations[InstanceApprovedByAnnotation]).ShouldNot(BeEmpty())
+		Expect(approvedInst.Annotations[InstanceApprovedAtAnnotation]).ShouldNot(BeEmpty())
+	})
+
+	It("should not allow setting the approver without approving", func() {
+		updatedInst := &DBaaSInstance{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(inst), updatedInst)).Should(Succeed())
+		updatedInst.Annotations = map[string]string{InstanceApprovedByAnnotation: "someone"}
+		Expect(k8sClient.Update(ctx, updatedInst)).Should(Succeed())
+		Expect(updatedInst.Annotations).ShouldNot(HaveKey(InstanceApprovedByAnnotation))
+	})
+})
@@ -30,6 +30,22 @@ type DBaaSOperatorInventorySpec struct {
 
 	// The policy for this inventory.
 	Policy *DBaaSInventoryPolicy `json:"policy,omitempty"`
+
+	// +kubebuilder:validation:Enum=Block;Cascade;Orphan
+	// What happens to the DBaaSConnection and DBaaSInstance objects referencing this inventory when it is deleted.
+	// Block: The inventory is kept until the connections and instances are deleted. This is the default.
+	// Cascade: The connections and instances are deleted along with the inventory.
+	// Orphan: The inventory is deleted and the connections and instances are left in place.
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DBaaSOperatorInventoryStatus defines the observed state of a DBaaSInventory object.
+type DBaaSOperatorInventoryStatus struct {
+	// The status copied from the provider’s inventory.
+	DBaaSInventoryStatus `json:",inline"`
+
+	// The policy resolved for the inventory, set by the operator.
+	Policy *DBaaSInventoryPolicyStatus `json:"policy,omitempty"`
 }
 
 //+kubebuilder:storageversion
@@ -43,8 +59,8 @@ type DBaaSInventory struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   DBaaSOperatorInventorySpec `json:"spec,omitempty"`
-	Status DBaaSInventoryStatus       `json:"status,omitempty"`
+	Spec   DBaaSOperatorInventorySpec   `json:"spec,omitempty"`
+	Status DBaaSOperatorInventoryStatus `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
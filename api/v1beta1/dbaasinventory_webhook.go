@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,10 +32,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
-const (
-	providerNameKey = "spec.providerRef.name"
-)
-
 // log is for logging in this package.
 var dbaasinventorylog = logf.Log.WithName("dbaasinventory-resource")
 
@@ -46,13 +43,6 @@ func (r *DBaaSInventory) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	if WebhookAPIClient == nil {
 		WebhookAPIClient = mgr.GetClient()
 	}
-	// index inventory by `spec.providerRef.name`
-	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSInventory{}, providerNameKey, func(rawObj client.Object) []string {
-		inventory := rawObj.(*DBaaSInventory)
-		return []string{inventory.Spec.ProviderRef.Name}
-	}); err != nil {
-		return err
-	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -102,6 +92,17 @@ func validateInventory(inv *DBaaSInventory, oldInv *DBaaSInventory) error {
 			return err
 		}
 	}
+	// Check the providers allowed by policy, the updates of the metadata or status, or of an inventory being deleted,
+	// are not blocked by the policy
+	if oldInv == nil || (inv.DeletionTimestamp == nil && !reflect.DeepEqual(inv.Spec, oldInv.Spec)) {
+		policy, _, err := getEffectivePolicy(inv)
+		if err != nil {
+			return err
+		}
+		if errs := policy.Restrictions.ValidateProvider(inv.Spec.ProviderRef.Name, field.NewPath("spec").Child("providerRef").Child("name")); len(errs) > 0 {
+			return errs.ToAggregate()
+		}
+	}
 	// Check ns selector
 	if inv.Spec.Policy != nil {
 		if inv.Spec.Policy.Connections.NsSelector != nil {
@@ -128,7 +129,7 @@ func validateInventoryMandatoryFields(inv *DBaaSInventory, secret *corev1.Secret
 
 func validateRDS() error {
 	rdsInventoryList := &DBaaSInventoryList{}
-	if err := WebhookAPIClient.List(context.TODO(), rdsInventoryList, client.MatchingFields{providerNameKey: RdsRegistration}); err != nil {
+	if err := WebhookAPIClient.List(context.TODO(), rdsInventoryList, client.MatchingFields{InventoryProviderIndex: RdsRegistration}); err != nil {
 		return err
 	}
 	if len(rdsInventoryList.Items) > 0 {
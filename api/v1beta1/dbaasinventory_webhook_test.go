@@ -300,6 +300,14 @@ var _ = Describe("DBaaSInventory Webhook", func() {
 				err := k8sClient.Create(ctx, inv)
 				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: values: Invalid value: []string(nil): for 'in', 'notin' operators, values set can't be empty"))
 			})
+			It("provider not allowed by policy", func() {
+				inv := testDBaaSInventory.DeepCopy()
+				inv.Spec.Policy.Restrictions = &DBaaSPolicyRestrictions{
+					Providers: []string{"crunchy-bridge-registration"},
+				}
+				err := k8sClient.Create(ctx, inv)
+				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.providerRef.name: Forbidden: provider mongodb-atlas is not allowed by policy, allowed providers: crunchy-bridge-registration"))
+			})
 			It("missing required credential fields", func() {
 				err := k8sClient.Create(ctx, &testDBaaSInventory)
 				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.credentialsRef: Invalid value: v1beta1.LocalObjectReference{Name:\"testsecret\"}: credentialsRef is invalid: field1 is required in secret testsecret"))
@@ -342,6 +350,15 @@ var _ = Describe("DBaaSInventory Webhook", func() {
 					err := k8sClient.Update(ctx, inv)
 					Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: values: Invalid value: []string(nil): for 'in', 'notin' operators, values set can't be empty"))
 				})
+				It("update fails with provider not allowed by policy", func() {
+					inv := testDBaaSInventory.DeepCopy()
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(inv), inv)).Should(Succeed())
+					inv.Spec.Policy.Restrictions = &DBaaSPolicyRestrictions{
+						Providers: []string{"crunchy-bridge-registration"},
+					}
+					err := k8sClient.Update(ctx, inv)
+					Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.providerRef.name: Forbidden: provider mongodb-atlas is not allowed by policy, allowed providers: crunchy-bridge-registration"))
+				})
 			})
 			It("update fails with provider name change", func() {
 				inv := testDBaaSInventory.DeepCopy()
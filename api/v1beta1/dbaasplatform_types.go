@@ -53,8 +53,55 @@ const (
 	ResultSuccess    PlatformInstlnStatus = "success"
 	ResultFailed     PlatformInstlnStatus = "failed"
 	ResultInProgress PlatformInstlnStatus = "in progress"
+	ResultWaiting    PlatformInstlnStatus = "waiting"
+	ResultSkipped    PlatformInstlnStatus = "skipped"
 )
 
+// ClusterCapability defines a set of APIs, which the platforms rely on, served by the cluster.
+type ClusterCapability string
+
+// Cluster capabilities.
+const (
+	// CapabilityOLM is served by clusters running Operator Lifecycle Manager.
+	CapabilityOLM ClusterCapability = "OperatorLifecycleManager"
+	// CapabilityConsolePlugins is served by OpenShift clusters supporting console dynamic plugins.
+	CapabilityConsolePlugins ClusterCapability = "ConsolePlugins"
+	// CapabilityConsoleQuickStarts is served by OpenShift clusters supporting console quick starts.
+	CapabilityConsoleQuickStarts ClusterCapability = "ConsoleQuickStarts"
+	// CapabilityOpenShiftConfig is served by OpenShift clusters.
+	CapabilityOpenShiftConfig ClusterCapability = "OpenShiftConfig"
+)
+
+// DevTopologyMode defines how DBaaSConnection objects are represented in the Developer Topology view.
+type DevTopologyMode string
+
+// Developer Topology view modes.
+const (
+	// DevTopologyConnection labels the DBaaSConnection objects, so the console plugin shows them as Topology nodes.
+	DevTopologyConnection DevTopologyMode = "Connection"
+	// DevTopologyDeployment creates a zero-replica Deployment for each DBaaSConnection object.
+	DevTopologyDeployment DevTopologyMode = "Deployment"
+	// DevTopologyDisabled does not represent DBaaSConnection objects in the Topology view.
+	DevTopologyDisabled DevTopologyMode = "Disabled"
+)
+
+// InstallPlanApproval defines how the upgrades of a platform operator are approved.
+type InstallPlanApproval string
+
+// Install plan approval modes.
+const (
+	// InstallPlanApprovalAutomatic upgrades the operator when a new version is available in its catalog.
+	InstallPlanApprovalAutomatic InstallPlanApproval = "Automatic"
+	// InstallPlanApprovalManual upgrades the operator only to the starting or the target version.
+	InstallPlanApprovalManual InstallPlanApproval = "Manual"
+)
+
+// TopologyNodeLabelKey is the label set on DBaaSConnection objects shown as Topology nodes by the console plugin.
+// The dbaas-dynamic-plugin, installed as the DBaaSDynamicPluginInstallation platform, selects the connections
+// with this label to add them to the Developer Topology view. The label is managed by the DBaaSConnection
+// controller from the DevTopology mode of the DBaaSPlatform, and should not be set by users.
+const TopologyNodeLabelKey = "dbaas.redhat.com/topology-node"
+
 // PlatformConfig defines parameters for a platform.
 type PlatformConfig struct {
 	Name           string
@@ -66,6 +113,62 @@ type PlatformConfig struct {
 	DisplayName    string
 	Envs           []corev1.EnvVar
 	Type           PlatformType
+	DependsOn      []PlatformName
+	Approval       InstallPlanApproval
+	TargetCSV      string
+	Manifests      string
+}
+
+// PlatformSpec defines the installation settings of a platform.
+type PlatformSpec struct {
+	// The name of the platform.
+	// It is either one of the built-in platforms, crunchy-bridge, cockroachdb-cloud, rds-provider, dbaas-dynamic-plugin, dbaas-quick-starts and observability,
+	// or the name of an additional provider operator.
+	Name PlatformName `json:"name"`
+
+	// Whether the platform is installed. The default is true.
+	// Disabling a platform removes it, if it was installed.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// The package name of the operator in the catalog. It is required for additional provider operators.
+	PackageName string `json:"packageName,omitempty"`
+
+	// The subscription channel of the operator.
+	Channel string `json:"channel,omitempty"`
+
+	// The starting ClusterServiceVersion (CSV) of the operator, or the version of the dynamic plugin.
+	CSV string `json:"csv,omitempty"`
+
+	// The image of the catalog source providing the operator, or the image of the dynamic plugin.
+	CatalogImage string `json:"catalogImage,omitempty"`
+
+	// The name of the operator's deployment, used to check when the operator is ready.
+	DeploymentName string `json:"deploymentName,omitempty"`
+
+	// The display name of the catalog source.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// The platforms that must be installed before this platform.
+	// It replaces the dependencies of a built-in platform.
+	DependsOn []PlatformName `json:"dependsOn,omitempty"`
+
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	// How the upgrades of the operator are approved.
+	// Automatic: The operator is upgraded when a new version is available in its catalog. This is the default.
+	// Manual: The operator is only installed at the starting CSV, and upgraded to the target CSV.
+	// The pending upgrades are listed in the platform status.
+	InstallPlanApproval InstallPlanApproval `json:"installPlanApproval,omitempty"`
+
+	// The ClusterServiceVersion (CSV) approved for upgrade, when the install plan approval is Manual.
+	// The DBaaS Operator approves the pending install plan that installs this CSV.
+	TargetCSV string `json:"targetCSV,omitempty"`
+
+	// The name of a ConfigMap, in the namespace of the DBaaS Operator, holding the manifests of the operator,
+	// for example the output of helm template. Each entry of the ConfigMap holds one or more YAML documents.
+	// The manifests are applied instead of subscribing to the operator when Operator Lifecycle Manager is not available,
+	// otherwise the platform is skipped on those clusters. The manifests can only hold custom resource definitions,
+	// and service accounts, config maps, secrets, services, roles, role bindings and deployments in the namespace of the DBaaS Operator.
+	Manifests string `json:"manifests,omitempty"`
 }
 
 // ObservabilityConfig defines parameters for observatorium.
@@ -83,12 +186,61 @@ type DBaaSPlatformSpec struct {
 	// +kubebuilder:validation:Maximum=1440
 	// Sets the minimum interval, which the provider's operator controllers reconcile. The default value is 180 minutes.
 	SyncPeriod *int `json:"syncPeriod,omitempty"`
+
+	// +kubebuilder:validation:Enum=Connection;Deployment;Disabled
+	// How DBaaSConnection objects are represented in the Developer Topology view.
+	// Connection: The connections are labeled, and shown by the console plugin. This is the default.
+	// Deployment: A zero-replica Deployment is created for each connection. This is deprecated.
+	// Disabled: The connections are not shown in the Topology view.
+	DevTopology DevTopologyMode `json:"devTopology,omitempty"`
+
+	// The namespace of the catalog sources created for the provider operators. The default is openshift-marketplace.
+	// Operator Lifecycle Manager only uses catalog sources in its global catalog namespace, or in the namespace of the DBaaS Operator.
+	CatalogNamespace string `json:"catalogNamespace,omitempty"`
+
+	// Checks that the platform images are reachable, directly or through the image mirrors of the cluster, before installing the platforms.
+	// The platforms with unreachable images are not installed. Enable it on disconnected clusters.
+	// Image mirrors only apply to images pinned by digest.
+	VerifyImages bool `json:"verifyImages,omitempty"`
+
+	// +listType=map
+	// +listMapKey=name
+	// Overrides the installation settings of the built-in platforms, and adds provider operators to install.
+	// The built-in platforms that are not listed are installed with their default settings.
+	Platforms []PlatformSpec `json:"platforms,omitempty"`
 }
 
 // DBaaSPlatformStatus defines the observed state of a DBaaSPlatform object.
 type DBaaSPlatformStatus struct {
 	Conditions      []metav1.Condition `json:"conditions,omitempty"`
 	PlatformsStatus []PlatformStatus   `json:"platformsStatus"`
+
+	// The result of the platform images check, when verifyImages is enabled.
+	Images []ImageStatus `json:"images,omitempty"`
+
+	// The time when the platform images were last checked.
+	ImagesCheckTime *metav1.Time `json:"imagesCheckTime,omitempty"`
+
+	// The capabilities detected on the cluster. The platforms requiring a missing capability are skipped.
+	Capabilities []ClusterCapability `json:"capabilities,omitempty"`
+}
+
+// ImageStatus defines the result of the check of a platform image.
+type ImageStatus struct {
+	// The platform using the image.
+	PlatformName PlatformName `json:"platformName"`
+
+	// The image reference.
+	Image string `json:"image"`
+
+	// Whether the image is pinned by digest.
+	DigestPinned bool `json:"digestPinned"`
+
+	// Whether the image is reachable, directly or through an image mirror.
+	Reachable bool `json:"reachable"`
+
+	// The registries the image can be pulled from, in order, or the reason the image is unreachable.
+	Message string `json:"message,omitempty"`
 }
 
 // PlatformStatus defines the status of a DBaaSPlatform object.
@@ -96,6 +248,28 @@ type PlatformStatus struct {
 	PlatformName   PlatformName         `json:"platformName"`
 	PlatformStatus PlatformInstlnStatus `json:"platformStatus"`
 	LastMessage    string               `json:"lastMessage,omitempty"`
+
+	// The time when the current installation of the platform started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// The time when the current installation of the platform completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// The ClusterServiceVersion (CSV) of the installed operator.
+	InstalledCSV string `json:"installedCSV,omitempty"`
+
+	// The install plans of the operator waiting for approval.
+	PendingInstallPlans []PendingInstallPlan `json:"pendingInstallPlans,omitempty"`
+}
+
+// PendingInstallPlan defines an install plan of a platform operator waiting for approval.
+type PendingInstallPlan struct {
+	// The name of the install plan.
+	Name string `json:"name"`
+
+	// The ClusterServiceVersions (CSVs) installed by the install plan.
+	// Set one of them as the target CSV of the platform to approve the install plan.
+	CSVNames []string `json:"csvNames,omitempty"`
 }
 
 //+kubebuilder:storageversion
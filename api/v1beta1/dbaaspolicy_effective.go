@@ -0,0 +1,217 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetEffectivePolicy returns the policy applying to the inventory: the namespace policy, overridden by the inventory
+// policy, and limited by the ceilings of the cluster policies. Without inventory, it returns the policy applying to
+// the namespace. The namespace policy can be nil when the namespace has no active policy.
+// The connection namespaces are limited by name to the ones allowed by the cluster policies, the namespaces selected
+// by labels are checked with IsConnectionNamespaceAllowed when the connection namespaces are resolved.
+func GetEffectivePolicy(clusterPolicies []DBaaSClusterPolicy, policy *DBaaSPolicy, inventory *DBaaSInventory) *DBaaSPolicySpec {
+	effective := &DBaaSPolicySpec{}
+	if policy != nil {
+		policy.Spec.DeepCopyInto(effective)
+	}
+	if inventory != nil && inventory.Spec.Policy != nil {
+		// the inventory takes precedence over the namespace policy
+		effective.DisableProvisions = inventory.Spec.Policy.DisableProvisions
+		inventory.Spec.Policy.Connections.DeepCopyInto(&effective.Connections)
+		if inventory.Spec.Policy.RequireApproval != nil {
+			effective.RequireApproval = inventory.Spec.Policy.RequireApproval
+		}
+		effective.Restrictions = GetPolicyRestrictions(inventory, policy)
+		if inventory.Spec.Policy.EnforcementMode != nil {
+			effective.EnforcementMode = inventory.Spec.Policy.EnforcementMode
+		}
+	}
+
+	for i := range clusterPolicies {
+		ceiling := &clusterPolicies[i].Spec
+		if ceiling.DisableProvisions != nil && *ceiling.DisableProvisions {
+			effective.DisableProvisions = ceiling.DisableProvisions
+		}
+		if ceiling.RequireApproval != nil && *ceiling.RequireApproval {
+			effective.RequireApproval = ceiling.RequireApproval
+		}
+		if ceiling.EnforcementMode != nil && *ceiling.EnforcementMode == PolicyEnforcementEnforce {
+			effective.EnforcementMode = ceiling.EnforcementMode
+		}
+		effective.Restrictions = limitRestrictions(effective.Restrictions, ceiling.Restrictions)
+		effective.Quotas = limitQuotas(effective.Quotas, ceiling.Quotas)
+		effective.Connections = limitConnections(effective.Connections, ceiling.Connections)
+	}
+	return effective
+}
+
+// IsConnectionNamespaceAllowed returns true if the cluster policies allow the namespace to connect to the inventories
+// of other namespaces
+func IsConnectionNamespaceAllowed(clusterPolicies []DBaaSClusterPolicy, namespace *corev1.Namespace) (bool, error) {
+	for i := range clusterPolicies {
+		ceiling := clusterPolicies[i].Spec.Connections
+		if ceiling == nil {
+			continue
+		}
+		if ceiling.Namespaces != nil && (contains(*ceiling.Namespaces, "*") || contains(*ceiling.Namespaces, namespace.Name)) {
+			continue
+		}
+		if ceiling.NsSelector == nil {
+			return false, nil
+		}
+		selector, err := metav1.LabelSelectorAsSelector(ceiling.NsSelector)
+		if err != nil {
+			return false, err
+		}
+		if !selector.Matches(labels.Set(namespace.Labels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// LimitsConnectionNamespaces returns true if a cluster policy limits the namespaces allowed to connect to the inventories
+func LimitsConnectionNamespaces(clusterPolicies []DBaaSClusterPolicy) bool {
+	for i := range clusterPolicies {
+		ceiling := clusterPolicies[i].Spec.Connections
+		if ceiling != nil && (ceiling.Namespaces == nil || !contains(*ceiling.Namespaces, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// limitConnections returns the connection namespaces limited by name to the ones allowed by the ceiling,
+// the namespaces allowed by the selector of the ceiling are only known when the connection namespaces are resolved
+func limitConnections(connections DBaaSConnectionPolicy, ceiling *DBaaSConnectionPolicy) DBaaSConnectionPolicy {
+	if ceiling == nil || ceiling.NsSelector != nil || connections.Namespaces == nil {
+		return connections
+	}
+	allowed := []string{}
+	if ceiling.Namespaces != nil {
+		allowed = *ceiling.Namespaces
+	}
+	if contains(allowed, "*") {
+		return connections
+	}
+	var namespaces []string
+	if contains(*connections.Namespaces, "*") {
+		namespaces = append([]string{}, allowed...)
+	} else {
+		namespaces = limitValues(*connections.Namespaces, allowed)
+	}
+	connections.Namespaces = &namespaces
+	return connections
+}
+
+// limitRestrictions returns the restrictions limited to the values allowed by the ceiling
+func limitRestrictions(restrictions, ceiling *DBaaSPolicyRestrictions) *DBaaSPolicyRestrictions {
+	if ceiling == nil {
+		return restrictions
+	}
+	if restrictions == nil {
+		return ceiling.DeepCopy()
+	}
+	return &DBaaSPolicyRestrictions{
+		Providers:      limitValues(restrictions.Providers, ceiling.Providers),
+		Plans:          limitValues(restrictions.Plans, ceiling.Plans),
+		CloudProviders: limitValues(restrictions.CloudProviders, ceiling.CloudProviders),
+		Regions:        limitValues(restrictions.Regions, ceiling.Regions),
+		MachineTypes:   limitValues(restrictions.MachineTypes, ceiling.MachineTypes),
+		MaxNodes:       limitInt32(restrictions.MaxNodes, ceiling.MaxNodes),
+		MaxStorageGib:  limitInt32(restrictions.MaxStorageGib, ceiling.MaxStorageGib),
+	}
+}
+
+// limitQuotas returns the quotas limited to the ceiling
+func limitQuotas(quotas, ceiling *DBaaSPolicyQuotas) *DBaaSPolicyQuotas {
+	if ceiling == nil {
+		return quotas
+	}
+	if quotas == nil {
+		return ceiling.DeepCopy()
+	}
+	limited := &DBaaSPolicyQuotas{
+		MaxInstancesPerNamespace: limitInt32(quotas.MaxInstancesPerNamespace, ceiling.MaxInstancesPerNamespace),
+		MaxInstancesPerInventory: limitInt32(quotas.MaxInstancesPerInventory, ceiling.MaxInstancesPerInventory),
+		SpendLimit:               quotas.SpendLimit,
+	}
+	if ceiling.SpendLimit != nil && (quotas.SpendLimit == nil || ceiling.SpendLimit.Cmp(*quotas.SpendLimit) < 0) {
+		spendLimit := ceiling.SpendLimit.DeepCopy()
+		limited.SpendLimit = &spendLimit
+	}
+	return limited
+}
+
+// limitValues returns the values allowed by the ceiling, a nil list allows all values
+func limitValues(values, ceiling []string) []string {
+	if ceiling == nil {
+		return values
+	}
+	if values == nil {
+		return append([]string{}, ceiling...)
+	}
+	limited := []string{}
+	for _, value := range values {
+		if contains(ceiling, value) {
+			limited = append(limited, value)
+		}
+	}
+	return limited
+}
+
+// limitInt32 returns the lowest of the value and the ceiling, a nil value is unlimited
+func limitInt32(value, ceiling *int32) *int32 {
+	if ceiling == nil || (value != nil && *value <= *ceiling) {
+		return value
+	}
+	limited := *ceiling
+	return &limited
+}
+
+// GetActivePolicy returns the active policy among the policies of a namespace, or nil if there is none
+func GetActivePolicy(policies []DBaaSPolicy) *DBaaSPolicy {
+	for i := range policies {
+		if apimeta.IsStatusConditionTrue(policies[i].Status.Conditions, DBaaSPolicyReadyType) {
+			return &policies[i]
+		}
+	}
+	return nil
+}
+
+// getEffectivePolicy returns the policy applying to the inventory, from the active policy of its namespace and the cluster policies,
+// and the active policy, which can be nil
+func getEffectivePolicy(inventory *DBaaSInventory) (*DBaaSPolicySpec, *DBaaSPolicy, error) {
+	policyList := &DBaaSPolicyList{}
+	if err := WebhookAPIClient.List(context.TODO(), policyList, client.InNamespace(inventory.Namespace)); err != nil {
+		return nil, nil, err
+	}
+	policy := GetActivePolicy(policyList.Items)
+	clusterPolicyList := &DBaaSClusterPolicyList{}
+	if err := WebhookAPIClient.List(context.TODO(), clusterPolicyList); err != nil {
+		return nil, nil, err
+	}
+	return GetEffectivePolicy(clusterPolicyList.Items, policy, inventory), policy, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// GetPolicyUsage returns the usage of the quotas by the instances using the inventories of the namespace,
+// the instances being deleted are not counted
+func GetPolicyUsage(namespace string, instances []DBaaSInstance) *DBaaSPolicyUsage {
+	usage := &DBaaSPolicyUsage{}
+	for i := range instances {
+		inst := &instances[i]
+		if inst.DeletionTimestamp != nil || GetInstanceInventoryNamespace(inst) != namespace {
+			continue
+		}
+		if usage.InstancesPerNamespace == nil {
+			usage.InstancesPerNamespace = map[string]int32{}
+			usage.InstancesPerInventory = map[string]int32{}
+		}
+		usage.InstancesPerNamespace[inst.Namespace]++
+		usage.InstancesPerInventory[inst.Spec.InventoryRef.Name]++
+		if spendLimit, err := resource.ParseQuantity(inst.Spec.ProvisioningParameters[ProvisioningSpendLimit]); err == nil {
+			usage.SpendLimit.Add(spendLimit)
+		}
+	}
+	return usage
+}
+
+// GetInstanceInventoryNamespace returns the namespace of the inventory of an instance, the instance namespace by default
+func GetInstanceInventoryNamespace(inst *DBaaSInstance) string {
+	if len(inst.Spec.InventoryRef.Namespace) > 0 {
+		return inst.Spec.InventoryRef.Namespace
+	}
+	return inst.Namespace
+}
+
+// ValidateInstance checks that the quotas are not exhausted by adding the instance to the usage of the other instances.
+// The instance counts are only checked on creation, and the spend limit when it is changed.
+func (q *DBaaSPolicyQuotas) ValidateInstance(inst, oldInst *DBaaSInstance, usage *DBaaSPolicyUsage) field.ErrorList {
+	if q == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if oldInst == nil {
+		if q.MaxInstancesPerNamespace != nil && usage.InstancesPerNamespace[inst.Namespace] >= *q.MaxInstancesPerNamespace {
+			errs = append(errs, field.Forbidden(field.NewPath("metadata").Child("namespace"),
+				fmt.Sprintf("quota of %d instances in namespace %s is exhausted", *q.MaxInstancesPerNamespace, inst.Namespace)))
+		}
+		if q.MaxInstancesPerInventory != nil && usage.InstancesPerInventory[inst.Spec.InventoryRef.Name] >= *q.MaxInstancesPerInventory {
+			errs = append(errs, field.Forbidden(field.NewPath("spec").Child("inventoryRef"),
+				fmt.Sprintf("quota of %d instances for inventory %s is exhausted", *q.MaxInstancesPerInventory, inst.Spec.InventoryRef.Name)))
+		}
+	}
+
+	value, ok := inst.Spec.ProvisioningParameters[ProvisioningSpendLimit]
+	if q.SpendLimit == nil || !ok || (oldInst != nil && value == oldInst.Spec.ProvisioningParameters[ProvisioningSpendLimit]) {
+		return errs
+	}
+	spendLimitPath := field.NewPath("spec").Child("provisioningParameters").Key(string(ProvisioningSpendLimit))
+	spendLimit, err := resource.ParseQuantity(value)
+	if err != nil {
+		return append(errs, field.Invalid(spendLimitPath, value, "spend limit must be a number"))
+	}
+	total := usage.SpendLimit.DeepCopy()
+	total.Add(spendLimit)
+	if total.Cmp(*q.SpendLimit) > 0 {
+		errs = append(errs, field.Forbidden(spendLimitPath,
+			fmt.Sprintf("spend limit budget of %s is exhausted, %s is already used", q.SpendLimit.String(), usage.SpendLimit.String())))
+	}
+	return errs
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// GetPolicyRestrictions returns the restrictions applying to the inventory, the fields set by the inventory policy
+// override the ones set by the namespace policy. It returns nil if there is no restriction.
+func GetPolicyRestrictions(inventory *DBaaSInventory, policy *DBaaSPolicy) *DBaaSPolicyRestrictions {
+	var restrictions *DBaaSPolicyRestrictions
+	if policy != nil && policy.Spec.Restrictions != nil {
+		restrictions = policy.Spec.Restrictions.DeepCopy()
+	}
+	if inventory == nil || inventory.Spec.Policy == nil || inventory.Spec.Policy.Restrictions == nil {
+		return restrictions
+	}
+	override := inventory.Spec.Policy.Restrictions
+	if restrictions == nil {
+		return override.DeepCopy()
+	}
+	if override.Providers != nil {
+		restrictions.Providers = override.Providers
+	}
+	if override.Plans != nil {
+		restrictions.Plans = override.Plans
+	}
+	if override.CloudProviders != nil {
+		restrictions.CloudProviders = override.CloudProviders
+	}
+	if override.Regions != nil {
+		restrictions.Regions = override.Regions
+	}
+	if override.MachineTypes != nil {
+		restrictions.MachineTypes = override.MachineTypes
+	}
+	if override.MaxNodes != nil {
+		restrictions.MaxNodes = override.MaxNodes
+	}
+	if override.MaxStorageGib != nil {
+		restrictions.MaxStorageGib = override.MaxStorageGib
+	}
+	return restrictions
+}
+
+// ValidateProvider checks that the restrictions allow an inventory to use the provider
+func (r *DBaaSPolicyRestrictions) ValidateProvider(providerName string, providerPath *field.Path) field.ErrorList {
+	if r == nil || r.Providers == nil || contains(r.Providers, providerName) {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(providerPath, fmt.Sprintf("provider %s is not allowed by policy, allowed providers: %s",
+		providerName, strings.Join(r.Providers, ", ")))}
+}
+
+// ValidateProvisioningParameters checks the provisioning parameters of an instance against the restrictions,
+// the parameters that are not set are not checked
+func (r *DBaaSPolicyRestrictions) ValidateProvisioningParameters(params map[ProvisioningParameterType]string, paramsPath *field.Path) field.ErrorList {
+	if r == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	validateValues := func(param ProvisioningParameterType, allowed []string) {
+		value, ok := params[param]
+		if !ok || allowed == nil {
+			return
+		}
+		for _, v := range strings.Split(value, ",") {
+			if !contains(allowed, strings.TrimSpace(v)) {
+				errs = append(errs, field.Forbidden(paramsPath.Key(string(param)),
+					fmt.Sprintf("value %s is not allowed by policy, allowed values: %s", strings.TrimSpace(v), strings.Join(allowed, ", "))))
+				return
+			}
+		}
+	}
+	validateMax := func(param ProvisioningParameterType, max *int32) {
+		value, ok := params[param]
+		if !ok || max == nil {
+			return
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || n > int(*max) {
+			errs = append(errs, field.Forbidden(paramsPath.Key(string(param)),
+				fmt.Sprintf("value %s exceeds the maximum of %d allowed by policy", value, *max)))
+		}
+	}
+
+	validateValues(ProvisioningPlan, r.Plans)
+	validateValues(ProvisioningCloudProvider, r.CloudProviders)
+	validateValues(ProvisioningRegions, r.Regions)
+	validateValues(ProvisioningMachineType, r.MachineTypes)
+	validateMax(ProvisioningNodes, r.MaxNodes)
+	validateMax(ProvisioningStorageGib, r.MaxStorageGib)
+	return errs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
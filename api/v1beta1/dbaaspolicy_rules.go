@@ -0,0 +1,223 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Variables available to the expressions of the policy rules
+const (
+	ruleObjectVariable          = "object"
+	ruleInventoryVariable       = "inventory"
+	ruleNamespaceLabelsVariable = "namespaceLabels"
+)
+
+// Kinds of objects checked by the policy rules
+const (
+	ruleInstanceKind   = "DBaaSInstance"
+	ruleConnectionKind = "DBaaSConnection"
+)
+
+const (
+	// ruleCostLimit is the maximum cost of the evaluation of a rule
+	ruleCostLimit = 1000000
+	// ruleMaxSize is the size assumed for the strings, lists and maps of the checked objects to estimate the cost of a rule
+	ruleMaxSize = 1000
+)
+
+var (
+	policyRuleEnvOnce sync.Once
+	policyRuleEnv     *cel.Env
+	policyRuleEnvErr  error
+
+	// policyRulePrograms caches the programs compiled from the rules of the policies, by policy UID
+	policyRulePrograms      = map[types.UID]*compiledPolicyRules{}
+	policyRuleProgramsMutex sync.Mutex
+)
+
+// compiledPolicyRules are the programs compiled from the rules of a policy generation, or the compile errors
+type compiledPolicyRules struct {
+	generation int64
+	programs   []cel.Program
+	errs       []error
+}
+
+// getPolicyRuleEnv returns the CEL environment of the policy rules, which is only created once
+func getPolicyRuleEnv() (*cel.Env, error) {
+	policyRuleEnvOnce.Do(func() {
+		policyRuleEnv, policyRuleEnvErr = cel.NewEnv(
+			cel.Variable(ruleObjectVariable, cel.DynType),
+			cel.Variable(ruleInventoryVariable, cel.DynType),
+			cel.Variable(ruleNamespaceLabelsVariable, cel.MapType(cel.StringType, cel.StringType)),
+		)
+	})
+	return policyRuleEnv, policyRuleEnvErr
+}
+
+// compilePolicyRule compiles the expression of a rule, which must evaluate to a boolean within the cost limit
+func compilePolicyRule(env *cel.Env, rule *DBaaSPolicyRule) (cel.Program, error) {
+	ast, issues := env.Compile(rule.Expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if outputType := ast.OutputType(); !outputType.IsAssignableType(cel.BoolType) {
+		return nil, fmt.Errorf("expression must evaluate to a boolean, not %s", outputType)
+	}
+	cost, err := env.EstimateCost(ast, ruleCostEstimator{})
+	if err != nil {
+		return nil, err
+	}
+	if cost.Max > ruleCostLimit {
+		return nil, fmt.Errorf("estimated cost %d exceeds the limit of %d", cost.Max, ruleCostLimit)
+	}
+	return env.Program(ast, cel.CostLimit(ruleCostLimit))
+}
+
+// getPolicyRulePrograms returns the programs compiled from the rules of the policy, they are compiled again when the
+// generation of the policy changes
+func getPolicyRulePrograms(env *cel.Env, policy *DBaaSPolicy) *compiledPolicyRules {
+	policyRuleProgramsMutex.Lock()
+	defer policyRuleProgramsMutex.Unlock()
+
+	if compiled, ok := policyRulePrograms[policy.UID]; ok && compiled.generation == policy.Generation {
+		return compiled
+	}
+	compiled := &compiledPolicyRules{
+		generation: policy.Generation,
+		programs:   make([]cel.Program, len(policy.Spec.Rules)),
+		errs:       make([]error, len(policy.Spec.Rules)),
+	}
+	for i := range policy.Spec.Rules {
+		compiled.programs[i], compiled.errs[i] = compilePolicyRule(env, &policy.Spec.Rules[i])
+	}
+	if len(policy.UID) > 0 {
+		policyRulePrograms[policy.UID] = compiled
+	}
+	return compiled
+}
+
+// ruleCostEstimator bounds the sizes of the checked objects, which are not known when the rules are compiled
+type ruleCostEstimator struct{}
+
+// EstimateSize returns the size assumed for the values of the checked objects
+func (ruleCostEstimator) EstimateSize(checker.AstNode) *checker.SizeEstimate {
+	return &checker.SizeEstimate{Min: 0, Max: ruleMaxSize}
+}
+
+// EstimateCallCost uses the default cost of the functions
+func (ruleCostEstimator) EstimateCallCost(string, string, *checker.AstNode, []checker.AstNode) *checker.CallEstimate {
+	return nil
+}
+
+// ValidatePolicyRules checks that the expressions of the rules compile, and that their estimated cost is within the limit
+func ValidatePolicyRules(rules []DBaaSPolicyRule, rulesPath *field.Path) field.ErrorList {
+	if len(rules) == 0 {
+		return nil
+	}
+	env, err := getPolicyRuleEnv()
+	if err != nil {
+		return field.ErrorList{field.InternalError(rulesPath, err)}
+	}
+	var errs field.ErrorList
+	for i := range rules {
+		rule := &rules[i]
+		if _, err := compilePolicyRule(env, rule); err != nil {
+			errs = append(errs, field.Invalid(rulesPath.Index(i).Child("expression"), rule.Expression, err.Error()))
+		}
+		for _, kind := range rule.Kinds {
+			if kind != ruleInstanceKind && kind != ruleConnectionKind {
+				errs = append(errs, field.NotSupported(rulesPath.Index(i).Child("kinds"), kind, []string{ruleInstanceKind, ruleConnectionKind}))
+			}
+		}
+	}
+	return errs
+}
+
+// CheckPolicyRules evaluates the rules of the policy checking the kind of the object, and returns the messages of the rules
+// denying it. A rule failing to evaluate, or exceeding the cost limit, denies the object.
+func CheckPolicyRules(policy *DBaaSPolicy, kind string, obj runtime.Object, inventory *DBaaSInventory, namespaceLabels map[string]string) field.ErrorList {
+	if policy == nil || len(policy.Spec.Rules) == 0 {
+		return nil
+	}
+	rulesPath := field.NewPath("rules")
+	env, err := getPolicyRuleEnv()
+	if err != nil {
+		return field.ErrorList{field.InternalError(rulesPath, err)}
+	}
+	compiled := getPolicyRulePrograms(env, policy)
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return field.ErrorList{field.InternalError(rulesPath, err)}
+	}
+	inventoryObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(inventory)
+	if err != nil {
+		return field.ErrorList{field.InternalError(rulesPath, err)}
+	}
+	if namespaceLabels == nil {
+		namespaceLabels = map[string]string{}
+	}
+	vars := map[string]interface{}{
+		ruleObjectVariable:          object,
+		ruleInventoryVariable:       inventoryObject,
+		ruleNamespaceLabelsVariable: namespaceLabels,
+	}
+
+	var errs field.ErrorList
+	for i := range policy.Spec.Rules {
+		rule := &policy.Spec.Rules[i]
+		if len(rule.Kinds) > 0 && !contains(rule.Kinds, kind) {
+			continue
+		}
+		rulePath := rulesPath.Key(rule.Name)
+		if err := compiled.errs[i]; err != nil {
+			errs = append(errs, field.Forbidden(rulePath, fmt.Sprintf("%s (invalid rule: %v)", rule.Message, err)))
+			continue
+		}
+		result, _, err := compiled.programs[i].Eval(vars)
+		if err != nil {
+			errs = append(errs, field.Forbidden(rulePath, fmt.Sprintf("%s (evaluation error: %v)", rule.Message, err)))
+			continue
+		}
+		if allowed, ok := result.Value().(bool); !ok || !allowed {
+			errs = append(errs, field.Forbidden(rulePath, rule.Message))
+		}
+	}
+	return errs
+}
+
+// getNamespaceLabels returns the labels of a namespace, or nil if it does not exist
+func getNamespaceLabels(namespace string) (map[string]string, error) {
+	ns := &corev1.Namespace{}
+	if err := WebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ns.Labels, nil
+}
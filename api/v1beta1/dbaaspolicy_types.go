@@ -17,12 +17,55 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // DBaaSPolicySpec the specifications for a DBaaSPolicy object.
 type DBaaSPolicySpec struct {
 	DBaaSInventoryPolicy `json:",inline"`
+
+	// Quotas on the DBaaSInstance objects using the inventories of the namespace.
+	Quotas *DBaaSPolicyQuotas `json:"quotas,omitempty"`
+
+	// Custom admission rules checked on the DBaaSInstance and DBaaSConnection objects using the inventories of the namespace.
+	// +listType=map
+	// +listMapKey=name
+	Rules []DBaaSPolicyRule `json:"rules,omitempty"`
+}
+
+// DBaaSPolicyRule is a custom admission rule, written as a Common Expression Language (CEL) expression.
+type DBaaSPolicyRule struct {
+	// The name of the rule.
+	Name string `json:"name"`
+
+	// The kinds of objects checked by the rule: DBaaSInstance or DBaaSConnection. Both kinds are checked when not set.
+	Kinds []string `json:"kinds,omitempty"`
+
+	// The CEL expression admitting the object when it evaluates to true. The expression can use the variables:
+	// object, the DBaaSInstance or DBaaSConnection object; inventory, the DBaaSInventory object it references;
+	// and namespaceLabels, the labels of the namespace of the object.
+	// For example: "!('env' in namespaceLabels) || namespaceLabels.env != 'dev' || object.spec.provisioningParameters.plan == 'FREETRIAL'"
+	Expression string `json:"expression"`
+
+	// The message returned when the rule denies the object.
+	Message string `json:"message"`
+}
+
+// DBaaSPolicyQuotas sets quotas on the DBaaSInstance objects using the inventories of a namespace.
+// A quota that is not set is unlimited. The quotas are checked when the instances are created or updated,
+// so instances created concurrently can exceed them.
+type DBaaSPolicyQuotas struct {
+	// +kubebuilder:validation:Minimum=0
+	// The maximum number of DBaaSInstance objects in each namespace.
+	MaxInstancesPerNamespace *int32 `json:"maxInstancesPerNamespace,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// The maximum number of DBaaSInstance objects using each inventory.
+	MaxInstancesPerInventory *int32 `json:"maxInstancesPerInventory,omitempty"`
+
+	// The budget for the sum of the spendLimit provisioning parameters of the DBaaSInstance objects.
+	SpendLimit *resource.Quantity `json:"spendLimit,omitempty"`
 }
 
 // DBaaSInventoryPolicy sets the inventory policy.
@@ -31,6 +74,53 @@ type DBaaSInventoryPolicy struct {
 	DisableProvisions *bool `json:"disableProvisions,omitempty"`
 	// Namespaces where DBaaSConnection and DBaaSInstance objects are only allowed to reference a policy's inventories.
 	Connections DBaaSConnectionPolicy `json:"connections,omitempty"`
+	// Requires the approval of new DBaaSInstance objects before they are provisioned.
+	// An instance is approved by a user allowed to approve DBaaSInstance objects in the namespace of the inventory.
+	RequireApproval *bool `json:"requireApproval,omitempty"`
+	// Restricts the providers of the inventories, and the provisioning parameters of the instances.
+	Restrictions *DBaaSPolicyRestrictions `json:"restrictions,omitempty"`
+	// +kubebuilder:validation:Enum=Warn;Enforce
+	// How the existing DBaaSConnection and DBaaSInstance objects falling out of policy are handled.
+	// Warn sets a condition on the objects, Enforce also revokes the provider connections. Defaults to Warn.
+	EnforcementMode *PolicyEnforcementMode `json:"enforcementMode,omitempty"`
+}
+
+// PolicyEnforcementMode defines how the objects falling out of policy are handled.
+type PolicyEnforcementMode string
+
+// Constants for the policy enforcement modes.
+const (
+	// PolicyEnforcementWarn sets a condition on the objects falling out of policy.
+	PolicyEnforcementWarn PolicyEnforcementMode = "Warn"
+	// PolicyEnforcementEnforce also revokes the provider connections of the DBaaSConnection objects falling out of policy.
+	PolicyEnforcementEnforce PolicyEnforcementMode = "Enforce"
+)
+
+// DBaaSPolicyRestrictions restricts the providers of the inventories, and the provisioning parameters of the instances.
+// The restrictions set by an inventory override the ones of the policy. A list that is not set allows all values.
+type DBaaSPolicyRestrictions struct {
+	// The names of the DBaaSProvider objects that the inventories are allowed to use.
+	Providers []string `json:"providers,omitempty"`
+
+	// The provisioning plans allowed for the instances: FREETRIAL, SERVERLESS or DEDICATED.
+	Plans []string `json:"plans,omitempty"`
+
+	// The cloud providers allowed for the instances, for example AWS or GCP.
+	CloudProviders []string `json:"cloudProviders,omitempty"`
+
+	// The regions allowed for the instances.
+	Regions []string `json:"regions,omitempty"`
+
+	// The machine types allowed for the instances.
+	MachineTypes []string `json:"machineTypes,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// The maximum number of nodes of the instances.
+	MaxNodes *int32 `json:"maxNodes,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// The maximum storage of the instances, in GiB.
+	MaxStorageGib *int32 `json:"maxStorageGib,omitempty"`
 }
 
 // DBaaSConnectionPolicy sets a connection policy.
@@ -51,6 +141,25 @@ type DBaaSConnectionPolicy struct {
 // DBaaSPolicyStatus defines the observed state of a DBaaSPolicy object.
 type DBaaSPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The policy applying to the namespace, limited by the ceilings of the DBaaSClusterPolicy objects.
+	// The inventory policies can override it within the same ceilings.
+	EffectivePolicy *DBaaSPolicySpec `json:"effectivePolicy,omitempty"`
+
+	// The usage of the quotas, reported by the active policy.
+	Usage *DBaaSPolicyUsage `json:"usage,omitempty"`
+}
+
+// DBaaSPolicyUsage reports the DBaaSInstance objects using the inventories of a namespace.
+type DBaaSPolicyUsage struct {
+	// The number of DBaaSInstance objects, by namespace.
+	InstancesPerNamespace map[string]int32 `json:"instancesPerNamespace,omitempty"`
+
+	// The number of DBaaSInstance objects, by inventory name.
+	InstancesPerInventory map[string]int32 `json:"instancesPerInventory,omitempty"`
+
+	// The sum of the spendLimit provisioning parameters of the DBaaSInstance objects.
+	SpendLimit resource.Quantity `json:"spendLimit,omitempty"`
 }
 
 //+kubebuilder:storageversion
@@ -19,6 +19,7 @@ package v1beta1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -63,5 +64,5 @@ func validatePolicy(policy *DBaaSPolicy) error {
 			return err
 		}
 	}
-	return nil
+	return ValidatePolicyRules(policy.Spec.Rules, field.NewPath("spec").Child("rules")).ToAggregate()
 }
@@ -50,6 +50,33 @@ var _ = Describe("DBaaSPolicy Webhook", func() {
 				err := k8sClient.Create(ctx, policy)
 				Expect(err).Should(MatchError("admission webhook \"vdbaaspolicy.kb.io\" denied the request: values: Invalid value: []string(nil): for 'in', 'notin' operators, values set can't be empty"))
 			})
+			It("rule expression not evaluating to a boolean", func() {
+				policy := testDBaaSPolicy.DeepCopy()
+				policy.SetResourceVersion("")
+				policy.Spec = DBaaSPolicySpec{
+					Rules: []DBaaSPolicyRule{{Name: "test-rule", Expression: "1", Message: "test message"}},
+				}
+				err := k8sClient.Create(ctx, policy)
+				Expect(err).Should(MatchError("admission webhook \"vdbaaspolicy.kb.io\" denied the request: spec.rules[0].expression: Invalid value: \"1\": expression must evaluate to a boolean, not int"))
+			})
+			It("rule expression exceeding the cost limit", func() {
+				policy := testDBaaSPolicy.DeepCopy()
+				policy.SetResourceVersion("")
+				policy.Spec = DBaaSPolicySpec{
+					Rules: []DBaaSPolicyRule{{Name: "test-rule", Expression: "object.spec.x.all(a, object.spec.y.all(b, a == b))", Message: "test message"}},
+				}
+				err := k8sClient.Create(ctx, policy)
+				Expect(err).Should(MatchError("admission webhook \"vdbaaspolicy.kb.io\" denied the request: spec.rules[0].expression: Invalid value: \"object.spec.x.all(a, object.spec.y.all(b, a == b))\": estimated cost 105005002 exceeds the limit of 1000000"))
+			})
+			It("rule with an unsupported kind", func() {
+				policy := testDBaaSPolicy.DeepCopy()
+				policy.SetResourceVersion("")
+				policy.Spec = DBaaSPolicySpec{
+					Rules: []DBaaSPolicyRule{{Name: "test-rule", Kinds: []string{"DBaaSInventory"}, Expression: "true", Message: "test message"}},
+				}
+				err := k8sClient.Create(ctx, policy)
+				Expect(err).Should(MatchError("admission webhook \"vdbaaspolicy.kb.io\" denied the request: spec.rules[0].kinds: Unsupported value: \"DBaaSInventory\": supported values: \"DBaaSInstance\", \"DBaaSConnection\""))
+			})
 		})
 	Context("without optional fields", func() {
 		It("should succeed without optional fields", func() {
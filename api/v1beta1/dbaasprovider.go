@@ -17,7 +17,10 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,8 +33,16 @@ const (
 	DBaaSConnectionProviderSyncType string = "ReadyForBinding"
 	DBaaSInstanceReadyType          string = "InstanceReady"
 	DBaaSInstanceProviderSyncType   string = "ProvisionReady"
+	DBaaSBackupReadyType            string = "BackupReady"
+	DBaaSBackupProviderSyncType     string = "BackupSynced"
+	DBaaSRestoreReadyType           string = "RestoreReady"
+	DBaaSRestoreProviderSyncType    string = "RestoreSynced"
 	DBaaSPolicyReadyType            string = "PolicyReady"
 	DBaaSPlatformReadyType          string = "PlatformReady"
+	DBaaSPlatformImagesReadyType    string = "ImagesReachable"
+	DBaaSDeletionBlockedType        string = "DeletionBlocked"
+	DBaaSProviderReadyType          string = "ProviderReady"
+	DBaaSCredentialsRotatedType     string = "CredentialsRotated"
 
 	// DBaaS condition reasons:
 	Ready                          string = "Ready"
@@ -43,11 +54,25 @@ const (
 	DBaaSInventoryNotProvisionable string = "DBaaSInventoryNotProvisionable"
 	DBaaSInvalidNamespace          string = "InvalidNamespace"
 	DBaaSServiceNotAvailable       string = "DBaaSServiceNotAvailable"
+	DBaaSBackupNotAvailable        string = "DBaaSBackupNotAvailable"
+	DBaaSProviderKindNotSupported  string = "DBaaSProviderKindNotSupported"
 	ProviderReconcileInprogress    string = "ProviderReconcileInprogress"
 	ProviderReconcileError         string = "ProviderReconcileError"
 	ProviderParsingError           string = "ProviderParsingError"
 	InstallationInprogress         string = "InstallationInprogress"
 	InstallationCleanup            string = "InstallationCleanup"
+	InvalidPlatformSettings        string = "InvalidPlatformSettings"
+	ImagesUnreachable              string = "ImagesUnreachable"
+	DBaaSDependentsExist           string = "DependentsExist"
+	DBaaSDeletingDependents        string = "DeletingDependents"
+	DBaaSBindingError              string = "BindingError"
+	DBaaSProviderCRDsMissing       string = "CRDsMissing"
+	DBaaSProviderUnsupportedAPI    string = "UnsupportedAPIVersion"
+	DBaaSProviderOperatorNotReady  string = "OperatorNotReady"
+	DBaaSPolicyViolation           string = "PolicyViolation"
+	DBaaSInstancePendingApproval   string = "PendingApproval"
+	DBaaSRotationPending           string = "RotationPending"
+	DBaaSRotationTimedOut          string = "RotationTimedOut"
 
 	// DBaaS condition messages
 	MsgProviderCRStatusSyncDone      string = "Provider Custom Resource status sync completed"
@@ -57,17 +82,54 @@ const (
 	MsgPolicyNotFound                string = "Failed to find an active Policy"
 	MsgPolicyReady                   string = "Policy is active"
 	MsgInvalidNamespace              string = "Invalid connection namespace for the referenced inventory"
+	MsgConnectionRevoked             string = "The provider connection is revoked by policy enforcement"
 	MsgPolicyNotReady                string = "Another active Policy already exists"
+	MsgBackupNotSupported            string = "The DBaaS Provider does not support backups"
+	MsgInstancePendingApproval       string = "Waiting for the approval of the instance"
+	MsgRestoreNotSupported           string = "The DBaaS Provider does not support restores"
+	MsgDeletionBlocked               string = "Deletion is blocked until the dependent objects are deleted"
+	MsgDeletingDependents            string = "Deleting the dependent objects"
+	MsgRotationPending               string = "Waiting for the provider to issue new credentials"
+	MsgCredentialsRotated            string = "The provider issued new credentials"
 
 	TypeLabelValue    = "credentials"
 	TypeLabelKey      = "db-operator/type"
 	TypeLabelKeyMongo = "atlas.mongodb.com/type"
 
+	// DBaaSDependentsFinalizer is set on inventories and instances to handle their dependent objects on deletion.
+	DBaaSDependentsFinalizer = "dbaas.redhat.com/dependents"
+
+	// DBaaSPlatformFinalizer is set on the platform to remove the dependents finalizers when the operator is uninstalled.
+	DBaaSPlatformFinalizer = "dbaas.redhat.com/platform"
+
+	// RotateCredentialsAnnotation requests new credentials for a DBaaSConnection, the value identifies the request.
+	// The DBaaS Operator copies it to the provider connection object.
+	RotateCredentialsAnnotation = "dbaas.redhat.com/rotate-credentials"
+	// DefaultedProvisioningParametersAnnotation lists the provisioning parameters of a DBaaSInstance that were set to the provider's default values.
+	DefaultedProvisioningParametersAnnotation = "dbaas.redhat.com/defaulted-provisioning-parameters"
+	// CredentialsRotatedAtAnnotation is set on the pod template of bound workloads to restart them after a credentials rotation.
+	CredentialsRotatedAtAnnotation = "dbaas.redhat.com/credentials-rotated-at"
+	// InstanceApprovedAnnotation approves a DBaaSInstance when set to true by a user allowed to approve the instances of its inventory.
+	InstanceApprovedAnnotation = "dbaas.redhat.com/approved"
+	// InstanceApprovedByAnnotation is set by the DBaaS Operator to the user who approved a DBaaSInstance.
+	InstanceApprovedByAnnotation = "dbaas.redhat.com/approved-by"
+	// InstanceApprovedAtAnnotation is set by the DBaaS Operator to the time a DBaaSInstance was approved.
+	InstanceApprovedAtAnnotation = "dbaas.redhat.com/approved-at"
+
 	ProvisioningPlanFreeTrial  string = "FREETRIAL"
 	ProvisioningPlanServerless string = "SERVERLESS"
 	ProvisioningPlanDedicated  string = "DEDICATED"
 )
 
+// GetCredentialsLabelKey returns the key of the label set on the inventory credentials secrets of a provider,
+// with the TypeLabelValue value, so the provider operator can watch them
+func GetCredentialsLabelKey(providerName string) string {
+	if strings.Contains(providerName, "mongodb") {
+		return TypeLabelKeyMongo
+	}
+	return TypeLabelKey
+}
+
 // ProvisioningParameterType defines teh type for provisioning parameters
 type ProvisioningParameterType string
 
@@ -108,6 +170,46 @@ const (
 	InstancePhaseReady    DBaasInstancePhase = "Ready"
 	InstancePhaseError    DBaasInstancePhase = "Error"
 	InstancePhaseFailed   DBaasInstancePhase = "Failed"
+
+	InstancePhasePendingApproval DBaasInstancePhase = "PendingApproval"
+)
+
+// DeletionPolicy defines what happens to the dependent objects when a DBaaS object is deleted.
+type DeletionPolicy string
+
+// Constants for the deletion policies.
+const (
+	// DeletionPolicyBlock keeps the object until all dependent objects are deleted.
+	DeletionPolicyBlock DeletionPolicy = "Block"
+	// DeletionPolicyCascade deletes the dependent objects along with the object.
+	DeletionPolicyCascade DeletionPolicy = "Cascade"
+	// DeletionPolicyOrphan deletes the object and leaves the dependent objects in place.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// DBaaSBackupPhase defines the phases for a database backup.
+type DBaaSBackupPhase string
+
+// Constants for the backup phases.
+const (
+	BackupPhaseUnknown    DBaaSBackupPhase = "Unknown"
+	BackupPhasePending    DBaaSBackupPhase = "Pending"
+	BackupPhaseInProgress DBaaSBackupPhase = "InProgress"
+	BackupPhaseCompleted  DBaaSBackupPhase = "Completed"
+	BackupPhaseDeleting   DBaaSBackupPhase = "Deleting"
+	BackupPhaseFailed     DBaaSBackupPhase = "Failed"
+)
+
+// DBaaSRestorePhase defines the phases for restoring a database from a backup.
+type DBaaSRestorePhase string
+
+// Constants for the restore phases.
+const (
+	RestorePhaseUnknown    DBaaSRestorePhase = "Unknown"
+	RestorePhasePending    DBaaSRestorePhase = "Pending"
+	RestorePhaseInProgress DBaaSRestorePhase = "InProgress"
+	RestorePhaseCompleted  DBaaSRestorePhase = "Completed"
+	RestorePhaseFailed     DBaaSRestorePhase = "Failed"
 )
 
 // DBaaSProviderSpec defines the desired state of a DBaaSProvider object.
@@ -128,6 +230,14 @@ type DBaaSProviderSpec struct {
 	// The name of the instance's custom resource definition (CRD) as defined by the provider for provisioning.
 	InstanceKind string `json:"instanceKind"`
 
+	// The name of the backup's custom resource definition (CRD) as defined by the provider.
+	// Leave empty if the provider does not support backups.
+	BackupKind string `json:"backupKind,omitempty"`
+
+	// The name of the restore's custom resource definition (CRD) as defined by the provider.
+	// Leave empty if the provider does not support restoring from backups.
+	RestoreKind string `json:"restoreKind,omitempty"`
+
 	// Indicates what information to collect from the user interface and how to display fields in a form.
 	CredentialFields []CredentialField `json:"credentialFields"`
 
@@ -142,11 +252,21 @@ type DBaaSProviderSpec struct {
 
 	// Parameter specifications used by the user interface (UI) for provisioning a database instance.
 	ProvisioningParameters map[ProvisioningParameterType]ProvisioningParameter `json:"provisioningParameters,omitempty"`
+
+	// The name of the provider operator's deployment, in the namespace of the DBaaS Operator.
+	// It is checked to report the health of the provider. Leave empty to skip the check.
+	OperatorDeploymentName string `json:"operatorDeploymentName,omitempty"`
 }
 
 // DBaaSProviderStatus defines the observed state of DBaaSProvider object.
 type DBaaSProviderStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The number of inventories using the provider.
+	Inventories int32 `json:"inventories,omitempty"`
+
+	// The number of inventories using the provider that are ready.
+	ReadyInventories int32 `json:"readyInventories,omitempty"`
 }
 
 // DatabaseProviderInfo defines the information for a DBaaSProvider object.
@@ -213,6 +333,37 @@ type DBaaSInventoryStatus struct {
 	DatabaseServices []DatabaseService `json:"databaseServices,omitempty"`
 }
 
+// DBaaSInventoryPolicyStatus reports the policy resolved for an inventory.
+type DBaaSInventoryPolicyStatus struct {
+	// The source of the policy: Inventory when the inventory policy overrides the namespace policy,
+	// Policy when the active DBaaSPolicy of the namespace applies, or None when there is no active policy.
+	// +kubebuilder:validation:Enum=Inventory;Policy;None
+	Source DBaaSInventoryPolicySource `json:"source"`
+
+	// The name of the active DBaaSPolicy in the namespace of the inventory.
+	PolicyName string `json:"policyName,omitempty"`
+
+	// Indicates whether provisioning is allowed against the inventory.
+	ProvisioningAllowed bool `json:"provisioningAllowed"`
+
+	// The namespaces allowed to connect to the inventory.
+	// An asterisk ('*') indicates that all namespaces are allowed.
+	ConnectionNamespaces []string `json:"connectionNamespaces,omitempty"`
+}
+
+// DBaaSInventoryPolicySource is the source of the policy resolved for an inventory.
+type DBaaSInventoryPolicySource string
+
+// Constants for the inventory policy sources.
+const (
+	// InventoryPolicySourceInventory indicates that the inventory policy overrides the namespace policy.
+	InventoryPolicySourceInventory DBaaSInventoryPolicySource = "Inventory"
+	// InventoryPolicySourcePolicy indicates that the active DBaaSPolicy of the namespace applies.
+	InventoryPolicySourcePolicy DBaaSInventoryPolicySource = "Policy"
+	// InventoryPolicySourceNone indicates that there is no active DBaaSPolicy in the namespace.
+	InventoryPolicySourceNone DBaaSInventoryPolicySource = "None"
+)
+
 // DatabaseService defines the information of a database service.
 type DatabaseService struct {
 	// A provider-specific identifier for the database service.
@@ -264,6 +415,44 @@ type DBaaSConnectionSpec struct {
 
 	// The type of the database service to connect to, as seen in the status of the referenced DBaaSInventory.
 	DatabaseServiceType *DatabaseServiceType `json:"databaseServiceType,omitempty"`
+
+	// Settings for rotating the connection credentials.
+	// If not set, credentials are only rotated on demand by setting the dbaas.redhat.com/rotate-credentials annotation.
+	Rotation *CredentialsRotation `json:"rotation,omitempty"`
+
+	// +listType=set
+	// The connection string formats published in the binding secret, when they apply to the type of the database.
+	// If not set, all the formats that apply to the type of the database are published.
+	ConnectionStringFormats []ConnectionStringFormat `json:"connectionStringFormats,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=JDBC;DSN;SQLAlchemy;MongoDB;DotNet
+// ConnectionStringFormat defines a connection string format published in the binding secret of a DBaaSConnection.
+type ConnectionStringFormat string
+
+// Constants for the connection string formats.
+const (
+	// ConnectionStringJDBC is a JDBC URL, published as the jdbc-url entry.
+	ConnectionStringJDBC ConnectionStringFormat = "JDBC"
+	// ConnectionStringDSN is a Go driver data source name, which is a libpq connection string for PostgreSQL, published as the dsn entry.
+	ConnectionStringDSN ConnectionStringFormat = "DSN"
+	// ConnectionStringSQLAlchemy is a SQLAlchemy database URL, published as the sqlalchemy-url entry.
+	ConnectionStringSQLAlchemy ConnectionStringFormat = "SQLAlchemy"
+	// ConnectionStringMongoDB is a MongoDB connection string URI, published as the mongodb-uri entry.
+	ConnectionStringMongoDB ConnectionStringFormat = "MongoDB"
+	// ConnectionStringDotNet is an ADO.NET connection string, published as the dotnet-connection-string entry.
+	ConnectionStringDotNet ConnectionStringFormat = "DotNet"
+)
+
+// CredentialsRotation defines how the credentials of a DBaaSConnection are rotated.
+type CredentialsRotation struct {
+	// The interval between automatic credentials rotations, for example 720h.
+	// If not set, credentials are only rotated on demand.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// How long the provider keeps the previous credentials valid after issuing new ones.
+	// The default is 1h.
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
 }
 
 // DBaaSConnectionStatus defines the observed state of a DBaaSConnection object.
@@ -275,6 +464,22 @@ type DBaaSConnectionStatus struct {
 
 	// A ConfigMap object holding non-sensitive information for connecting to the database instance.
 	ConnectionInfoRef *corev1.LocalObjectReference `json:"connectionInfoRef,omitempty"`
+
+	// The secret holding the previous account credentials, which stay valid until the rotation grace period ends.
+	PreviousCredentialsRef *corev1.LocalObjectReference `json:"previousCredentialsRef,omitempty"`
+
+	// The time when the credentials were last rotated.
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// The value of the dbaas.redhat.com/rotate-credentials annotation for the last completed or timed out rotation.
+	ObservedRotationRequest string `json:"observedRotationRequest,omitempty"`
+
+	// Whether the workloads bound to the connection still need to be restarted after the last credentials rotation.
+	WorkloadsRestartPending bool `json:"workloadsRestartPending,omitempty"`
+
+	// The secret generated by the DBaaS Operator, holding the connection information in the format of the servicebinding.io specification.
+	// It makes the connection a Provisioned Service.
+	Binding *corev1.LocalObjectReference `json:"binding,omitempty"`
 }
 
 // DBaaSProviderConnection defines the schema for a provider's connection status.
@@ -300,6 +505,13 @@ type DBaaSInstanceSpec struct {
 
 	// Parameters with values used for provisioning.
 	ProvisioningParameters map[ProvisioningParameterType]string `json:"provisioningParameters,omitempty"`
+
+	// +kubebuilder:validation:Enum=Block;Cascade;Orphan
+	// What happens to the DBaaSConnection objects referencing this instance when it is deleted.
+	// Block: The instance is kept until the connections are deleted. This is the default.
+	// Cascade: The connections are deleted along with the instance.
+	// Orphan: The instance is deleted and the connections are left in place.
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // DBaaSInstanceStatus defines the observed state of a DBaaSInstance.
@@ -313,7 +525,7 @@ type DBaaSInstanceStatus struct {
 	// Any other provider-specific information related to this instance.
 	InstanceInfo map[string]string `json:"instanceInfo,omitempty"`
 
-	// +kubebuilder:validation:Enum=Unknown;Pending;Creating;Updating;Deleting;Deleted;Ready;Error;Failed
+	// +kubebuilder:validation:Enum=Unknown;Pending;Creating;Updating;Deleting;Deleted;Ready;Error;Failed;PendingApproval
 	// +kubebuilder:default=Unknown
 	// Represents the following cluster provisioning phases.
 	// Unknown: An unknown cluster provisioning status.
@@ -325,7 +537,11 @@ type DBaaSInstanceStatus struct {
 	// Ready: Cluster provisioning is done.
 	// Error: Cluster provisioning error.
 	// Failed: Cluster provisioning failed.
+	// PendingApproval: Waiting for the approval of the instance, set by the DBaaS Operator.
 	Phase DBaasInstancePhase `json:"phase"`
+
+	// The approval of the instance, set by the DBaaS Operator when the inventory policy requires approvals.
+	Approval *DBaaSInstanceApprovalInfo `json:"approval,omitempty"`
 }
 
 // DBaaSProviderInstance defines the schema for a provider instance object.
@@ -336,6 +552,115 @@ type DBaaSProviderInstance struct {
 	Status DBaaSInstanceStatus `json:"status,omitempty"`
 }
 
+// DBaaSBackupSpec defines the desired state of a DBaaSBackup object.
+type DBaaSBackupSpec struct {
+	// A reference to the relevant DBaaSInventory custom resource (CR).
+	InventoryRef NamespacedName `json:"inventoryRef"`
+
+	// The ID of the database service to back up, as seen in the status of the referenced DBaaSInventory.
+	DatabaseServiceID string `json:"databaseServiceID,omitempty"`
+
+	// A reference to the DBaaSInstance CR to back up, if the DatabaseServiceID is not specified.
+	DatabaseServiceRef *NamespacedName `json:"databaseServiceRef,omitempty"`
+
+	// A cron expression for taking scheduled backups.
+	// If not set, a single on-demand backup is taken.
+	Schedule string `json:"schedule,omitempty"`
+
+	// The number of days the provider keeps the backup.
+	// If not set, the provider's default retention applies.
+	RetentionDays *int32 `json:"retentionDays,omitempty"`
+}
+
+// DBaaSBackupStatus defines the observed state of a DBaaSBackup object.
+type DBaaSBackupStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// A provider-specific identifier for the backup.
+	BackupID string `json:"backupID,omitempty"`
+
+	// +kubebuilder:validation:Enum=Unknown;Pending;InProgress;Completed;Deleting;Failed
+	// +kubebuilder:default=Unknown
+	// Represents the following backup phases.
+	// Unknown: An unknown backup status.
+	// Pending: In the queue, waiting for the backup to start.
+	// InProgress: The backup is in progress.
+	// Completed: The backup is done.
+	// Deleting: Backup deletion is in progress.
+	// Failed: The backup failed.
+	Phase DBaaSBackupPhase `json:"phase,omitempty"`
+
+	// The size of the backup.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// The time when the backup started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// The time when the backup completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Any other provider-specific information related to this backup.
+	BackupInfo map[string]string `json:"backupInfo,omitempty"`
+}
+
+// DBaaSProviderBackup defines the schema for a provider backup object.
+type DBaaSProviderBackup struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSBackupSpec   `json:"spec,omitempty"`
+	Status DBaaSBackupStatus `json:"status,omitempty"`
+}
+
+// DBaaSRestoreSpec defines the desired state of a DBaaSRestore object.
+type DBaaSRestoreSpec struct {
+	// A reference to the relevant DBaaSInventory custom resource (CR).
+	InventoryRef NamespacedName `json:"inventoryRef"`
+
+	// The ID of the backup to restore, as seen in the status of a DBaaSBackup.
+	BackupID string `json:"backupID,omitempty"`
+
+	// A reference to the DBaaSBackup CR to restore, if the BackupID is not specified.
+	BackupRef *NamespacedName `json:"backupRef,omitempty"`
+
+	// The ID of the database service to restore into, as seen in the status of the referenced DBaaSInventory.
+	DatabaseServiceID string `json:"databaseServiceID,omitempty"`
+
+	// A reference to the DBaaSInstance CR to restore into, if the DatabaseServiceID is not specified.
+	DatabaseServiceRef *NamespacedName `json:"databaseServiceRef,omitempty"`
+}
+
+// DBaaSRestoreStatus defines the observed state of a DBaaSRestore object.
+type DBaaSRestoreStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +kubebuilder:validation:Enum=Unknown;Pending;InProgress;Completed;Failed
+	// +kubebuilder:default=Unknown
+	// Represents the following restore phases.
+	// Unknown: An unknown restore status.
+	// Pending: In the queue, waiting for the restore to start.
+	// InProgress: The restore is in progress.
+	// Completed: The restore is done.
+	// Failed: The restore failed.
+	Phase DBaaSRestorePhase `json:"phase,omitempty"`
+
+	// The time when the restore started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// The time when the restore completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Any other provider-specific information related to this restore.
+	RestoreInfo map[string]string `json:"restoreInfo,omitempty"`
+}
+
+// DBaaSProviderRestore defines the schema for a provider restore object.
+type DBaaSProviderRestore struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSRestoreSpec   `json:"spec,omitempty"`
+	Status DBaaSRestoreStatus `json:"status,omitempty"`
+}
+
 // Option defines the value and display value for an option in a dropdown menu, radio button, or checkbox.
 type Option struct {
 	// Value of the option.
@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var dbaasrestorelog = logf.Log.WithName("dbaasrestore-resource")
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *DBaaSRestore) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasrestore,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasrestores,verbs=create;update,versions=v1beta1,name=vdbaasrestore.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DBaaSRestore{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSRestore) ValidateCreate() error {
+	dbaasrestorelog.Info("validate create", "name", r.Name)
+	return r.validateDBaaSRestoreSpec()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSRestore) ValidateUpdate(old runtime.Object) error {
+	dbaasrestorelog.Info("validate update", "name", r.Name)
+	if !reflect.DeepEqual(r.Spec, old.(*DBaaSRestore).Spec) {
+		return field.Forbidden(field.NewPath("spec"), "spec is immutable")
+	}
+	return nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSRestore) ValidateDelete() error {
+	dbaasrestorelog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func (r *DBaaSRestore) validateDBaaSRestoreSpec() error {
+	if len(r.Spec.BackupID) > 0 && r.Spec.BackupRef != nil && len(r.Spec.BackupRef.Name) > 0 {
+		return field.Invalid(field.NewPath("spec").Child("backupID"), r.Spec.BackupID, "both backupID and backupRef are specified")
+	}
+	if len(r.Spec.BackupID) == 0 && (r.Spec.BackupRef == nil || len(r.Spec.BackupRef.Name) == 0) {
+		return field.Invalid(field.NewPath("spec").Child("backupID"), r.Spec.BackupID, "either backupID or backupRef must be specified")
+	}
+	if err := validateLocalRef(field.NewPath("spec").Child("backupRef"), r.Spec.BackupRef, r.Namespace); err != nil {
+		return err
+	}
+	return validateServiceRef(r.Spec.DatabaseServiceID, r.Spec.DatabaseServiceRef, r.Namespace)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("DBaaSRestore Webhook", func() {
+	DescribeTable("checking invalid DBaaSRestore creations",
+		func(spec DBaaSRestoreSpec, expectedErr interface{}) {
+			spec.InventoryRef = NamespacedName{Name: inventoryName, Namespace: testNamespace}
+			restore := &DBaaSRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-restore",
+					Namespace: testNamespace,
+				},
+				Spec: spec,
+			}
+			Expect(k8sClient.Create(ctx, restore)).Should(MatchError(expectedErr))
+		},
+		Entry("not allow a restore without backup",
+			DBaaSRestoreSpec{
+				DatabaseServiceID: databaseServiceID,
+			},
+			"admission webhook \"vdbaasrestore.kb.io\" denied the request: "+
+				"spec.backupID: Invalid value: \"\": either backupID or backupRef must be specified"),
+		Entry("not allow a reference to a backup of another namespace",
+			DBaaSRestoreSpec{
+				BackupRef:         &NamespacedName{Name: "test-backup", Namespace: testNamespace2},
+				DatabaseServiceID: databaseServiceID,
+			},
+			"admission webhook \"vdbaasrestore.kb.io\" denied the request: "+
+				"spec.backupRef.namespace: Invalid value: \""+testNamespace2+"\": the reference must be in the namespace of the object"),
+		Entry("not allow a reference to an instance of another namespace",
+			DBaaSRestoreSpec{
+				BackupID:           "test-backupID",
+				DatabaseServiceRef: &NamespacedName{Name: databaseServiceName, Namespace: testNamespace2},
+			},
+			"admission webhook \"vdbaasrestore.kb.io\" denied the request: "+
+				"spec.databaseServiceRef.namespace: Invalid value: \""+testNamespace2+"\": the reference must be in the namespace of the object"),
+	)
+})
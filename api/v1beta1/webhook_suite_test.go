@@ -30,6 +30,7 @@ import (
 
 	operatorframework "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -95,6 +96,9 @@ var _ = BeforeSuite(func() {
 	err = corev1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = authorizationv1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = operatorframework.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -121,6 +125,9 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
+	err = SetupIndexes(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = (&DBaaSConnection{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -136,6 +143,15 @@ var _ = BeforeSuite(func() {
 	err = (&DBaaSProvider{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&DBaaSInstanceApproval{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&DBaaSBackup{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&DBaaSRestore{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	ns2 := corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: testNamespace2,
@@ -67,6 +67,261 @@ func (in *CredentialField) DeepCopy() *CredentialField {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsRotation) DeepCopyInto(out *CredentialsRotation) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsRotation.
+func (in *CredentialsRotation) DeepCopy() *CredentialsRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSBackup) DeepCopyInto(out *DBaaSBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackup.
+func (in *DBaaSBackup) DeepCopy() *DBaaSBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSBackupList) DeepCopyInto(out *DBaaSBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupList.
+func (in *DBaaSBackupList) DeepCopy() *DBaaSBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSBackupSpec) DeepCopyInto(out *DBaaSBackupSpec) {
+	*out = *in
+	out.InventoryRef = in.InventoryRef
+	if in.DatabaseServiceRef != nil {
+		in, out := &in.DatabaseServiceRef, &out.DatabaseServiceRef
+		*out = new(NamespacedName)
+		**out = **in
+	}
+	if in.RetentionDays != nil {
+		in, out := &in.RetentionDays, &out.RetentionDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupSpec.
+func (in *DBaaSBackupSpec) DeepCopy() *DBaaSBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSBackupStatus) DeepCopyInto(out *DBaaSBackupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BackupInfo != nil {
+		in, out := &in.BackupInfo, &out.BackupInfo
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupStatus.
+func (in *DBaaSBackupStatus) DeepCopy() *DBaaSBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSClusterPolicy) DeepCopyInto(out *DBaaSClusterPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSClusterPolicy.
+func (in *DBaaSClusterPolicy) DeepCopy() *DBaaSClusterPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSClusterPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSClusterPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSClusterPolicyList) DeepCopyInto(out *DBaaSClusterPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSClusterPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSClusterPolicyList.
+func (in *DBaaSClusterPolicyList) DeepCopy() *DBaaSClusterPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSClusterPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSClusterPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSClusterPolicySpec) DeepCopyInto(out *DBaaSClusterPolicySpec) {
+	*out = *in
+	if in.DisableProvisions != nil {
+		in, out := &in.DisableProvisions, &out.DisableProvisions
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequireApproval != nil {
+		in, out := &in.RequireApproval, &out.RequireApproval
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Restrictions != nil {
+		in, out := &in.Restrictions, &out.Restrictions
+		*out = new(DBaaSPolicyRestrictions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Connections != nil {
+		in, out := &in.Connections, &out.Connections
+		*out = new(DBaaSConnectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = new(DBaaSPolicyQuotas)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnforcementMode != nil {
+		in, out := &in.EnforcementMode, &out.EnforcementMode
+		*out = new(PolicyEnforcementMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSClusterPolicySpec.
+func (in *DBaaSClusterPolicySpec) DeepCopy() *DBaaSClusterPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSClusterPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSConnection) DeepCopyInto(out *DBaaSConnection) {
 	*out = *in
@@ -169,6 +424,16 @@ func (in *DBaaSConnectionSpec) DeepCopyInto(out *DBaaSConnectionSpec) {
 		*out = new(DatabaseServiceType)
 		**out = **in
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(CredentialsRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionStringFormats != nil {
+		in, out := &in.ConnectionStringFormats, &out.ConnectionStringFormats
+		*out = make([]ConnectionStringFormat, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionSpec.
@@ -201,6 +466,20 @@ func (in *DBaaSConnectionStatus) DeepCopyInto(out *DBaaSConnectionStatus) {
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.PreviousCredentialsRef != nil {
+		in, out := &in.PreviousCredentialsRef, &out.PreviousCredentialsRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Binding != nil {
+		in, out := &in.Binding, &out.Binding
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionStatus.
@@ -237,7 +516,113 @@ func (in *DBaaSInstance) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
-	return nil
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceApproval) DeepCopyInto(out *DBaaSInstanceApproval) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceApproval.
+func (in *DBaaSInstanceApproval) DeepCopy() *DBaaSInstanceApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInstanceApproval) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceApprovalInfo) DeepCopyInto(out *DBaaSInstanceApprovalInfo) {
+	*out = *in
+	in.ApprovalTime.DeepCopyInto(&out.ApprovalTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceApprovalInfo.
+func (in *DBaaSInstanceApprovalInfo) DeepCopy() *DBaaSInstanceApprovalInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceApprovalInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceApprovalList) DeepCopyInto(out *DBaaSInstanceApprovalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSInstanceApproval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceApprovalList.
+func (in *DBaaSInstanceApprovalList) DeepCopy() *DBaaSInstanceApprovalList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceApprovalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInstanceApprovalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceApprovalSpec) DeepCopyInto(out *DBaaSInstanceApprovalSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceApprovalSpec.
+func (in *DBaaSInstanceApprovalSpec) DeepCopy() *DBaaSInstanceApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceApprovalStatus) DeepCopyInto(out *DBaaSInstanceApprovalStatus) {
+	*out = *in
+	in.DBaaSInstanceApprovalInfo.DeepCopyInto(&out.DBaaSInstanceApprovalInfo)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceApprovalStatus.
+func (in *DBaaSInstanceApprovalStatus) DeepCopy() *DBaaSInstanceApprovalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceApprovalStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -312,6 +697,11 @@ func (in *DBaaSInstanceStatus) DeepCopyInto(out *DBaaSInstanceStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(DBaaSInstanceApprovalInfo)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceStatus.
@@ -392,6 +782,21 @@ func (in *DBaaSInventoryPolicy) DeepCopyInto(out *DBaaSInventoryPolicy) {
 		**out = **in
 	}
 	in.Connections.DeepCopyInto(&out.Connections)
+	if in.RequireApproval != nil {
+		in, out := &in.RequireApproval, &out.RequireApproval
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Restrictions != nil {
+		in, out := &in.Restrictions, &out.Restrictions
+		*out = new(DBaaSPolicyRestrictions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnforcementMode != nil {
+		in, out := &in.EnforcementMode, &out.EnforcementMode
+		*out = new(PolicyEnforcementMode)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryPolicy.
@@ -404,6 +809,26 @@ func (in *DBaaSInventoryPolicy) DeepCopy() *DBaaSInventoryPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryPolicyStatus) DeepCopyInto(out *DBaaSInventoryPolicyStatus) {
+	*out = *in
+	if in.ConnectionNamespaces != nil {
+		in, out := &in.ConnectionNamespaces, &out.ConnectionNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryPolicyStatus.
+func (in *DBaaSInventoryPolicyStatus) DeepCopy() *DBaaSInventoryPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSInventorySpec) DeepCopyInto(out *DBaaSInventorySpec) {
 	*out = *in
@@ -475,6 +900,27 @@ func (in *DBaaSOperatorInventorySpec) DeepCopy() *DBaaSOperatorInventorySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSOperatorInventoryStatus) DeepCopyInto(out *DBaaSOperatorInventoryStatus) {
+	*out = *in
+	in.DBaaSInventoryStatus.DeepCopyInto(&out.DBaaSInventoryStatus)
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(DBaaSInventoryPolicyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSOperatorInventoryStatus.
+func (in *DBaaSOperatorInventoryStatus) DeepCopy() *DBaaSOperatorInventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSOperatorInventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSPlatform) DeepCopyInto(out *DBaaSPlatform) {
 	*out = *in
@@ -542,6 +988,13 @@ func (in *DBaaSPlatformSpec) DeepCopyInto(out *DBaaSPlatformSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]PlatformSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformSpec.
@@ -567,6 +1020,22 @@ func (in *DBaaSPlatformStatus) DeepCopyInto(out *DBaaSPlatformStatus) {
 	if in.PlatformsStatus != nil {
 		in, out := &in.PlatformsStatus, &out.PlatformsStatus
 		*out = make([]PlatformStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImagesCheckTime != nil {
+		in, out := &in.ImagesCheckTime, &out.ImagesCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]ClusterCapability, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -640,10 +1109,122 @@ func (in *DBaaSPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPolicyQuotas) DeepCopyInto(out *DBaaSPolicyQuotas) {
+	*out = *in
+	if in.MaxInstancesPerNamespace != nil {
+		in, out := &in.MaxInstancesPerNamespace, &out.MaxInstancesPerNamespace
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxInstancesPerInventory != nil {
+		in, out := &in.MaxInstancesPerInventory, &out.MaxInstancesPerInventory
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SpendLimit != nil {
+		in, out := &in.SpendLimit, &out.SpendLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicyQuotas.
+func (in *DBaaSPolicyQuotas) DeepCopy() *DBaaSPolicyQuotas {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPolicyQuotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPolicyRestrictions) DeepCopyInto(out *DBaaSPolicyRestrictions) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plans != nil {
+		in, out := &in.Plans, &out.Plans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CloudProviders != nil {
+		in, out := &in.CloudProviders, &out.CloudProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MachineTypes != nil {
+		in, out := &in.MachineTypes, &out.MachineTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxNodes != nil {
+		in, out := &in.MaxNodes, &out.MaxNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxStorageGib != nil {
+		in, out := &in.MaxStorageGib, &out.MaxStorageGib
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicyRestrictions.
+func (in *DBaaSPolicyRestrictions) DeepCopy() *DBaaSPolicyRestrictions {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPolicyRestrictions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPolicyRule) DeepCopyInto(out *DBaaSPolicyRule) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicyRule.
+func (in *DBaaSPolicyRule) DeepCopy() *DBaaSPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSPolicySpec) DeepCopyInto(out *DBaaSPolicySpec) {
 	*out = *in
 	in.DBaaSInventoryPolicy.DeepCopyInto(&out.DBaaSInventoryPolicy)
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = new(DBaaSPolicyQuotas)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]DBaaSPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicySpec.
@@ -666,6 +1247,16 @@ func (in *DBaaSPolicyStatus) DeepCopyInto(out *DBaaSPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EffectivePolicy != nil {
+		in, out := &in.EffectivePolicy, &out.EffectivePolicy
+		*out = new(DBaaSPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(DBaaSPolicyUsage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicyStatus.
@@ -678,6 +1269,36 @@ func (in *DBaaSPolicyStatus) DeepCopy() *DBaaSPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPolicyUsage) DeepCopyInto(out *DBaaSPolicyUsage) {
+	*out = *in
+	if in.InstancesPerNamespace != nil {
+		in, out := &in.InstancesPerNamespace, &out.InstancesPerNamespace
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InstancesPerInventory != nil {
+		in, out := &in.InstancesPerInventory, &out.InstancesPerInventory
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.SpendLimit = in.SpendLimit.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicyUsage.
+func (in *DBaaSPolicyUsage) DeepCopy() *DBaaSPolicyUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPolicyUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSProvider) DeepCopyInto(out *DBaaSProvider) {
 	*out = *in
@@ -705,6 +1326,24 @@ func (in *DBaaSProvider) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderBackup) DeepCopyInto(out *DBaaSProviderBackup) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderBackup.
+func (in *DBaaSProviderBackup) DeepCopy() *DBaaSProviderBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSProviderConnection) DeepCopyInto(out *DBaaSProviderConnection) {
 	*out = *in
@@ -731,60 +1370,187 @@ func (in *DBaaSProviderInstance) DeepCopyInto(out *DBaaSProviderInstance) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderInstance.
-func (in *DBaaSProviderInstance) DeepCopy() *DBaaSProviderInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderInstance.
+func (in *DBaaSProviderInstance) DeepCopy() *DBaaSProviderInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderInventory) DeepCopyInto(out *DBaaSProviderInventory) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderInventory.
+func (in *DBaaSProviderInventory) DeepCopy() *DBaaSProviderInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderList) DeepCopyInto(out *DBaaSProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderList.
+func (in *DBaaSProviderList) DeepCopy() *DBaaSProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderRestore) DeepCopyInto(out *DBaaSProviderRestore) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderRestore.
+func (in *DBaaSProviderRestore) DeepCopy() *DBaaSProviderRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderSpec) DeepCopyInto(out *DBaaSProviderSpec) {
+	*out = *in
+	out.Provider = in.Provider
+	if in.CredentialFields != nil {
+		in, out := &in.CredentialFields, &out.CredentialFields
+		*out = make([]CredentialField, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProvisioningParameters != nil {
+		in, out := &in.ProvisioningParameters, &out.ProvisioningParameters
+		*out = make(map[ProvisioningParameterType]ProvisioningParameter, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderSpec.
+func (in *DBaaSProviderSpec) DeepCopy() *DBaaSProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderStatus) DeepCopyInto(out *DBaaSProviderStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderStatus.
+func (in *DBaaSProviderStatus) DeepCopy() *DBaaSProviderStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSProviderInstance)
+	out := new(DBaaSProviderStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSProviderInventory) DeepCopyInto(out *DBaaSProviderInventory) {
+func (in *DBaaSRestore) DeepCopyInto(out *DBaaSRestore) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderInventory.
-func (in *DBaaSProviderInventory) DeepCopy() *DBaaSProviderInventory {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestore.
+func (in *DBaaSRestore) DeepCopy() *DBaaSRestore {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSProviderInventory)
+	out := new(DBaaSRestore)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSProviderList) DeepCopyInto(out *DBaaSProviderList) {
+func (in *DBaaSRestoreList) DeepCopyInto(out *DBaaSRestoreList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBaaSProvider, len(*in))
+		*out = make([]DBaaSRestore, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderList.
-func (in *DBaaSProviderList) DeepCopy() *DBaaSProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestoreList.
+func (in *DBaaSRestoreList) DeepCopy() *DBaaSRestoreList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSProviderList)
+	out := new(DBaaSRestoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSProviderList) DeepCopyObject() runtime.Object {
+func (in *DBaaSRestoreList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -792,35 +1558,33 @@ func (in *DBaaSProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSProviderSpec) DeepCopyInto(out *DBaaSProviderSpec) {
+func (in *DBaaSRestoreSpec) DeepCopyInto(out *DBaaSRestoreSpec) {
 	*out = *in
-	out.Provider = in.Provider
-	if in.CredentialFields != nil {
-		in, out := &in.CredentialFields, &out.CredentialFields
-		*out = make([]CredentialField, len(*in))
-		copy(*out, *in)
+	out.InventoryRef = in.InventoryRef
+	if in.BackupRef != nil {
+		in, out := &in.BackupRef, &out.BackupRef
+		*out = new(NamespacedName)
+		**out = **in
 	}
-	if in.ProvisioningParameters != nil {
-		in, out := &in.ProvisioningParameters, &out.ProvisioningParameters
-		*out = make(map[ProvisioningParameterType]ProvisioningParameter, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.DatabaseServiceRef != nil {
+		in, out := &in.DatabaseServiceRef, &out.DatabaseServiceRef
+		*out = new(NamespacedName)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderSpec.
-func (in *DBaaSProviderSpec) DeepCopy() *DBaaSProviderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestoreSpec.
+func (in *DBaaSRestoreSpec) DeepCopy() *DBaaSRestoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSProviderSpec)
+	out := new(DBaaSRestoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSProviderStatus) DeepCopyInto(out *DBaaSProviderStatus) {
+func (in *DBaaSRestoreStatus) DeepCopyInto(out *DBaaSRestoreStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -829,14 +1593,29 @@ func (in *DBaaSProviderStatus) DeepCopyInto(out *DBaaSProviderStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestoreInfo != nil {
+		in, out := &in.RestoreInfo, &out.RestoreInfo
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderStatus.
-func (in *DBaaSProviderStatus) DeepCopy() *DBaaSProviderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestoreStatus.
+func (in *DBaaSRestoreStatus) DeepCopy() *DBaaSRestoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSProviderStatus)
+	out := new(DBaaSRestoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -899,6 +1678,21 @@ func (in *FieldDependency) DeepCopy() *FieldDependency {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageStatus) DeepCopyInto(out *ImageStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageStatus.
+func (in *ImageStatus) DeepCopy() *ImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instance) DeepCopyInto(out *Instance) {
 	*out = *in
@@ -921,6 +1715,183 @@ func (in *Instance) DeepCopy() *Instance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalConnection) DeepCopyInto(out *LocalConnection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalConnection.
+func (in *LocalConnection) DeepCopy() *LocalConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalConnection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalConnectionList) DeepCopyInto(out *LocalConnectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LocalConnection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalConnectionList.
+func (in *LocalConnectionList) DeepCopy() *LocalConnectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalConnectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalConnectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalInstance) DeepCopyInto(out *LocalInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalInstance.
+func (in *LocalInstance) DeepCopy() *LocalInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalInstanceList) DeepCopyInto(out *LocalInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LocalInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalInstanceList.
+func (in *LocalInstanceList) DeepCopy() *LocalInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalInventory) DeepCopyInto(out *LocalInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalInventory.
+func (in *LocalInventory) DeepCopy() *LocalInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalInventoryList) DeepCopyInto(out *LocalInventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LocalInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalInventoryList.
+func (in *LocalInventoryList) DeepCopy() *LocalInventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalInventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalObjectReference) DeepCopyInto(out *LocalObjectReference) {
 	*out = *in
@@ -981,6 +1952,26 @@ func (in *Option) DeepCopy() *Option {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingInstallPlan) DeepCopyInto(out *PendingInstallPlan) {
+	*out = *in
+	if in.CSVNames != nil {
+		in, out := &in.CSVNames, &out.CSVNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingInstallPlan.
+func (in *PendingInstallPlan) DeepCopy() *PendingInstallPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingInstallPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlatformConfig) DeepCopyInto(out *PlatformConfig) {
 	*out = *in
@@ -991,6 +1982,11 @@ func (in *PlatformConfig) DeepCopyInto(out *PlatformConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]PlatformName, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformConfig.
@@ -1003,9 +1999,49 @@ func (in *PlatformConfig) DeepCopy() *PlatformConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformSpec) DeepCopyInto(out *PlatformSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]PlatformName, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformSpec.
+func (in *PlatformSpec) DeepCopy() *PlatformSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
 	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingInstallPlans != nil {
+		in, out := &in.PendingInstallPlans, &out.PendingInstallPlans
+		*out = make([]PendingInstallPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
@@ -54,6 +54,8 @@ const (
 	testInventoryV1alpha1Kind  = "RDSInventory"
 	testConnectionV1alpha1Kind = "RDSConnection"
 	testInstanceV1alpha1Kind   = "RDSInstance"
+	testBackupProviderName     = "test-backup-provider"
+	testBackupKind             = "CrunchyBridgeBackup"
 )
 
 var crunchyProvider = &v1beta1.DBaaSProvider{
@@ -113,6 +115,26 @@ var ccapiProvider = &v1beta1.DBaaSProvider{
 	},
 }
 
+var backupProvider = &v1beta1.DBaaSProvider{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: testBackupProviderName,
+	},
+	Spec: v1beta1.DBaaSProviderSpec{
+		Provider: v1beta1.DatabaseProviderInfo{
+			Name: testBackupProviderName,
+		},
+		InventoryKind:                testInventoryKind,
+		ConnectionKind:               testConnectionKind,
+		InstanceKind:                 testInstanceKind,
+		BackupKind:                   testBackupKind,
+		CredentialFields:             []v1beta1.CredentialField{},
+		AllowsFreeTrial:              false,
+		ExternalProvisionURL:         "",
+		ExternalProvisionDescription: "",
+		GroupVersion:                 v1beta1.GroupVersion.String(),
+	},
+}
+
 var defaultPolicy = getDefaultPolicy(testNamespace)
 
 func assertResourceCreationIfNotExists(object client.Object) func() {
@@ -275,6 +297,13 @@ func assertProviderResourceCreated(object client.Object, groupVersion schema.Gro
 			default:
 				Fail("invalid test object")
 			}
+		case *v1beta1.DBaaSBackup:
+			providerBackup := &v1beta1.DBaaSProviderBackup{}
+			err := json.Unmarshal(bytes, providerBackup)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(&providerBackup.Spec).Should(Equal(DBaaSResourceSpec))
+			Expect(len(providerBackup.GetOwnerReferences())).Should(Equal(1))
+			Expect(providerBackup.GetOwnerReferences()[0].Name).Should(Equal(object.GetName()))
 		default:
 			_ = v.GetName() // to avoid syntax error
 			Fail("invalid test object")
@@ -305,6 +334,12 @@ func assertDBaaSResourceStatusUpdated(object client.Object, status metav1.Condit
 			case *v1beta1.DBaaSPolicy:
 				dbaasConds, _ := splitStatusConditions(v.Status.Conditions, v1beta1.DBaaSPolicyReadyType)
 				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
+			case *v1beta1.DBaaSBackup:
+				dbaasConds, _ := splitStatusConditions(v.Status.Conditions, v1beta1.DBaaSBackupReadyType)
+				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
+			case *v1beta1.DBaaSRestore:
+				dbaasConds, _ := splitStatusConditions(v.Status.Conditions, v1beta1.DBaaSRestoreReadyType)
+				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
 			default:
 				Fail("invalid test object")
 				return false, err
@@ -452,7 +487,7 @@ func assertInstanceDBaaSStatus(name, namespace string, dbaasStatus metav1.Condit
 
 func assertInventoryStatus(inv *v1beta1.DBaaSInventory, condType string, dbaasStatus metav1.ConditionStatus, providerResourceStatus interface{}) func() {
 	return func() {
-		status := inv.Status.DeepCopy()
+		status := inv.Status.DBaaSInventoryStatus.DeepCopy()
 		dbaasConds, providerConds := splitStatusConditions(status.Conditions, condType)
 		Expect(len(dbaasConds)).Should(Equal(1))
 		Expect(dbaasConds[0].Type).Should(Equal(condType))
@@ -637,6 +672,20 @@ func (c *spyctrl) watched(w *watchable) bool {
 	return false
 }
 
+func (c *spyctrl) count(w *watchable) int {
+	c.mutex.Lock()
+	values := c.values
+	c.mutex.Unlock()
+
+	count := 0
+	for _, value := range values {
+		if reflect.DeepEqual(w, value) {
+			count++
+		}
+	}
+	return count
+}
+
 func (c *spyctrl) delete(w *watchable) bool {
 	c.mutex.Lock()
 
@@ -659,6 +708,8 @@ func (c *spyctrl) Watch(src source.Source, evthdler handler.EventHandler, prct .
 	switch s := src.(type) {
 	case *source.Kind:
 		w.source = s.Type
+	case *providerKindSource:
+		w.source = s.Type
 	default:
 		Fail("unexpected source type")
 	}
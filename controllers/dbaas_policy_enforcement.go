@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// enforcePolicy revokes the provider connection of a DBaaSConnection falling out of policy when the effective policy
+// is enforced, and returns true if the connection is revoked. The other DBaaS objects are only reported out of policy.
+// Without an active policy, for example while the policy of the namespace is replaced, the connection is only revoked
+// when the cluster policies exclude it.
+func (r *DBaaSReconciler) enforcePolicy(ctx context.Context, inventory *v1beta1.DBaaSInventory, activePolicy *v1beta1.DBaaSPolicy,
+	effectivePolicy *v1beta1.DBaaSPolicySpec, DBaaSObject client.Object, logger logr.Logger) (bool, error) {
+	connection, ok := DBaaSObject.(*v1beta1.DBaaSConnection)
+	if !ok || effectivePolicy.EnforcementMode == nil || *effectivePolicy.EnforcementMode != v1beta1.PolicyEnforcementEnforce {
+		return false, nil
+	}
+	if activePolicy == nil {
+		if excluded, err := r.isExcludedByClusterPolicies(ctx, inventory, connection); err != nil || !excluded {
+			return false, err
+		}
+	}
+	provider, err := r.getDBaaSProvider(ctx, inventory.Spec.ProviderRef.Name)
+	if err != nil {
+		return false, err
+	}
+
+	providerObject := r.createProviderObject(connection, provider.GetDBaaSAPIGroupVersion(), provider.Spec.ConnectionKind)
+	if err := r.Delete(ctx, providerObject); err == nil {
+		logger.Info("Provider connection revoked by policy enforcement", "Provider Object", providerObject)
+	} else if !errors.IsNotFound(err) {
+		return false, err
+	}
+	if connection.Status.Binding != nil {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: connection.Status.Binding.Name, Namespace: connection.Namespace}}
+		if err := r.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	connection.Status.CredentialsRef = nil
+	connection.Status.ConnectionInfoRef = nil
+	connection.Status.PreviousCredentialsRef = nil
+	connection.Status.Binding = nil
+	return true, nil
+}
+
+// isExcludedByClusterPolicies returns true if the cluster policies exclude the namespace or the inventory of the connection
+func (r *DBaaSReconciler) isExcludedByClusterPolicies(ctx context.Context, inventory *v1beta1.DBaaSInventory, connection *v1beta1.DBaaSConnection) (bool, error) {
+	var clusterPolicyList v1beta1.DBaaSClusterPolicyList
+	if err := r.List(ctx, &clusterPolicyList); err != nil {
+		return false, err
+	}
+	clusterPolicy := v1beta1.GetEffectivePolicy(clusterPolicyList.Items, nil, nil)
+	if len(checkPolicyRestrictions(inventory, clusterPolicy, connection)) > 0 {
+		return true, nil
+	}
+	if connection.Namespace == inventory.Namespace {
+		return false, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: connection.Namespace}, ns); err != nil {
+		return false, err
+	}
+	allowed, err := v1beta1.IsConnectionNamespaceAllowed(clusterPolicyList.Items, ns)
+	return !allowed, err
+}
+
+// policyWatches adds the watches re-evaluating the DBaaS objects referencing inventories, listed with newObjectList,
+// when the policies, the inventory policies or the namespace labels change
+func (r *DBaaSReconciler) policyWatches(bldr *builder.Builder, newObjectList func() client.ObjectList) *builder.Builder {
+	return bldr.
+		Watches(&source.Kind{Type: &v1beta1.DBaaSPolicy{}}, handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			return r.inventoryDependentRequests(newObjectList(), func(inventory types.NamespacedName) bool {
+				return inventory.Namespace == o.GetNamespace()
+			})
+		}), builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, activePolicyChangedPredicate))).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSClusterPolicy{}}, handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			return r.inventoryDependentRequests(newObjectList(), func(types.NamespacedName) bool {
+				return true
+			})
+		}), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInventory{}}, handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			return r.inventoryDependentRequests(newObjectList(), func(inventory types.NamespacedName) bool {
+				return inventory == client.ObjectKeyFromObject(o)
+			})
+		}), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			return r.inventoryDependentRequests(newObjectList(), func(types.NamespacedName) bool {
+				return true
+			}, client.InNamespace(o.GetName()))
+		}), builder.WithPredicates(predicate.LabelChangedPredicate{}))
+}
+
+// activePolicyChangedPredicate filters the policy updates changing which policy is active
+var activePolicyChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPolicy, ok := e.ObjectOld.(*v1beta1.DBaaSPolicy)
+		if !ok {
+			return false
+		}
+		newPolicy, ok := e.ObjectNew.(*v1beta1.DBaaSPolicy)
+		if !ok {
+			return false
+		}
+		return apimeta.IsStatusConditionTrue(oldPolicy.Status.Conditions, v1beta1.DBaaSPolicyReadyType) !=
+			apimeta.IsStatusConditionTrue(newPolicy.Status.Conditions, v1beta1.DBaaSPolicyReadyType)
+	},
+}
+
+// inventoryDependentRequests enqueues the DBaaSConnection or DBaaSInstance objects referencing an inventory matched by inventoryFn
+func (r *DBaaSReconciler) inventoryDependentRequests(objectList client.ObjectList, inventoryFn func(types.NamespacedName) bool,
+	opts ...client.ListOption) []reconcile.Request {
+	if err := r.List(context.Background(), objectList, opts...); err != nil {
+		ctrl.Log.Error(err, "Error listing DBaaS objects for the policy change")
+		return nil
+	}
+	objects, err := apimeta.ExtractList(objectList)
+	if err != nil {
+		ctrl.Log.Error(err, "Error extracting DBaaS objects for the policy change")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, o := range objects {
+		var inventory types.NamespacedName
+		switch v := o.(type) {
+		case *v1beta1.DBaaSConnection:
+			inventory = inventoryRefName(v.Spec.InventoryRef, v.Namespace)
+		case *v1beta1.DBaaSInstance:
+			inventory = inventoryRefName(v.Spec.InventoryRef, v.Namespace)
+		default:
+			continue
+		}
+		if inventoryFn(inventory) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o.(client.Object))})
+		}
+	}
+	return requests
+}
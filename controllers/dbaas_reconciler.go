@@ -6,9 +6,9 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 
-	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
 	"github.com/go-logr/logr"
 
@@ -17,16 +17,18 @@ import (
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
-	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 // InstallNamespaceEnvVar is the constant for env variable INSTALL_NAMESPACE
@@ -34,8 +36,6 @@ var (
 	InstallNamespaceEnvVar = "INSTALL_NAMESPACE"
 )
 
-const rdsProvider string = "rds-registration"
-
 // DBaaSReconciler defines common methods used by other reconcilers
 type DBaaSReconciler struct {
 	client.Client
@@ -51,28 +51,6 @@ func (r *DBaaSReconciler) getDBaaSProvider(ctx context.Context, providerName str
 	return provider, nil
 }
 
-func (r *DBaaSReconciler) watchDBaaSProviderObject(ctrl controller.Controller, object runtime.Object, providerObjectKind string, providerGroupVersion *schema.GroupVersion) error {
-	providerObject := unstructured.Unstructured{}
-	providerObject.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   providerGroupVersion.Group,
-		Version: providerGroupVersion.Version,
-		Kind:    providerObjectKind,
-	})
-	err := ctrl.Watch(
-		&source.Kind{
-			Type: &providerObject,
-		},
-		&handler.EnqueueRequestForOwner{
-			OwnerType:    object,
-			IsController: true,
-		},
-	)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func (r *DBaaSReconciler) createProviderObject(object client.Object, groupVersion schema.GroupVersion, providerObjectKind string) *unstructured.Unstructured {
 	var providerObject unstructured.Unstructured
 	providerObject.SetGroupVersionKind(schema.GroupVersionKind{
@@ -88,6 +66,9 @@ func (r *DBaaSReconciler) createProviderObject(object client.Object, groupVersio
 func (r *DBaaSReconciler) providerObjectMutateFn(object client.Object, providerObject *unstructured.Unstructured, spec interface{}) controllerutil.MutateFn {
 	return func() error {
 		providerObject.UnstructuredContent()["spec"] = spec
+		if connection, ok := object.(*v1beta1.DBaaSConnection); ok {
+			setRotationRequest(connection, providerObject)
+		}
 		providerObject.SetOwnerReferences(nil)
 		return ctrl.SetControllerReference(object, providerObject, r.Scheme)
 	}
@@ -113,69 +94,134 @@ func (r *DBaaSReconciler) policyListByNS(ctx context.Context, namespace string)
 	return policyListByNS, nil
 }
 
+// getEffectivePolicy returns the policy applying to the inventory, from the active policy of its namespace and the cluster policies
+func (r *DBaaSReconciler) getEffectivePolicy(ctx context.Context, inventory *v1beta1.DBaaSInventory, activePolicy *v1beta1.DBaaSPolicy) (*v1beta1.DBaaSPolicySpec, error) {
+	var clusterPolicyList v1beta1.DBaaSClusterPolicyList
+	if err := r.List(ctx, &clusterPolicyList); err != nil {
+		return nil, err
+	}
+	return v1beta1.GetEffectivePolicy(clusterPolicyList.Items, activePolicy, inventory), nil
+}
+
 // check if namespace is a valid connection namespace
 func (r *DBaaSReconciler) isValidConnectionNS(ctx context.Context, namespace string, inventory *v1beta1.DBaaSInventory, activePolicy *v1beta1.DBaaSPolicy) (bool, error) {
 	// valid if in same namespace as inventory
 	if namespace == inventory.Namespace {
 		return true, nil
 	}
-	var validNamespaces []string
-	var validNsSelector *metav1.LabelSelector
+	validNamespaces, err := r.getConnectionNamespaces(ctx, inventory, activePolicy)
+	if err != nil {
+		return false, err
+	}
+	// valid if all namespaces are supported via wildcard
+	return contains(validNamespaces, "*") || contains(validNamespaces, namespace), nil
+}
+
+// getConnectionNamespaces returns the namespaces allowed to connect to the inventory, starting with the inventory namespace.
+// It returns only the wildcard when all namespaces are allowed. The namespaces are limited to the ones allowed by the cluster policies.
+func (r *DBaaSReconciler) getConnectionNamespaces(ctx context.Context, inventory *v1beta1.DBaaSInventory, activePolicy *v1beta1.DBaaSPolicy) ([]string, error) {
+	var connections v1beta1.DBaaSConnectionPolicy
 	if inventory.Spec.Policy != nil {
-		if inventory.Spec.Policy.Connections.Namespaces != nil {
-			validNamespaces = *inventory.Spec.Policy.Connections.Namespaces
-		}
-		if inventory.Spec.Policy.Connections.NsSelector != nil {
-			validNsSelector = inventory.Spec.Policy.Connections.NsSelector
+		connections = inventory.Spec.Policy.Connections
+	} else if activePolicy != nil {
+		connections = activePolicy.Spec.Connections
+	}
+
+	var clusterPolicyList v1beta1.DBaaSClusterPolicyList
+	if err := r.List(ctx, &clusterPolicyList); err != nil {
+		return nil, err
+	}
+	limited := v1beta1.LimitsConnectionNamespaces(clusterPolicyList.Items)
+
+	validNamespaces := []string{inventory.Namespace}
+	addNamespace := func(ns *corev1.Namespace) error {
+		if contains(validNamespaces, ns.Name) {
+			return nil
 		}
-	} else {
-		if activePolicy != nil {
-			if activePolicy.Spec.Connections.Namespaces != nil {
-				validNamespaces = *activePolicy.Spec.Connections.Namespaces
-			}
-			if activePolicy.Spec.Connections.NsSelector != nil {
-				validNsSelector = activePolicy.Spec.Connections.NsSelector
+		if limited {
+			if allowed, err := v1beta1.IsConnectionNamespaceAllowed(clusterPolicyList.Items, ns); err != nil || !allowed {
+				return err
 			}
 		}
+		validNamespaces = append(validNamespaces, ns.Name)
+		return nil
 	}
 
-	// valid if all namespaces are supported via wildcard
-	if contains(validNamespaces, "*") || contains(validNamespaces, namespace) {
-		return true, nil
+	var selectors []labels.Selector
+	if connections.Namespaces != nil {
+		if contains(*connections.Namespaces, "*") {
+			if !limited {
+				return []string{"*"}, nil
+			}
+			// all the namespaces allowed by the cluster policies
+			selectors = append(selectors, labels.Everything())
+		} else {
+			for _, name := range *connections.Namespaces {
+				ns := &corev1.Namespace{}
+				if err := r.Get(ctx, client.ObjectKey{Name: name}, ns); err != nil {
+					if !errors.IsNotFound(err) {
+						return nil, err
+					}
+					ns.Name = name
+				}
+				if err := addNamespace(ns); err != nil {
+					return nil, err
+				}
+			}
+		}
 	}
 
-	if validNsSelector != nil {
-		selector, err := metav1.LabelSelectorAsSelector(validNsSelector)
+	if connections.NsSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(connections.NsSelector)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
+		selectors = append(selectors, selector)
+	}
+	for _, selector := range selectors {
 		var selNS corev1.NamespaceList
 		if err := r.List(ctx, &selNS, &client.ListOptions{LabelSelector: selector}); err != nil {
-			return false, err
+			return nil, err
 		}
-		for _, ns := range selNS.Items {
-			validNamespaces = append(validNamespaces, ns.Name)
+		for i := range selNS.Items {
+			if err := addNamespace(&selNS.Items[i]); err != nil {
+				return nil, err
+			}
 		}
 	}
-	return contains(validNamespaces, namespace), nil
+	return validNamespaces, nil
 }
 
-// check if provisioning is allowed against an inventory. inventory takes precedence over dbaaspolicy.
-func canProvision(inventory *v1beta1.DBaaSInventory, activePolicy *v1beta1.DBaaSPolicy) bool {
+// getInventoryPolicyStatus returns the policy resolved for the inventory, with the namespaces currently allowed to connect
+func (r *DBaaSReconciler) getInventoryPolicyStatus(ctx context.Context, inventory *v1beta1.DBaaSInventory, activePolicy *v1beta1.DBaaSPolicy) (*v1beta1.DBaaSInventoryPolicyStatus, error) {
+	policyStatus := &v1beta1.DBaaSInventoryPolicyStatus{Source: v1beta1.InventoryPolicySourceNone}
+	if activePolicy != nil {
+		policyStatus.Source = v1beta1.InventoryPolicySourcePolicy
+		policyStatus.PolicyName = activePolicy.Name
+		if inventory.Spec.Policy != nil {
+			policyStatus.Source = v1beta1.InventoryPolicySourceInventory
+		}
+	}
+
+	effectivePolicy, err := r.getEffectivePolicy(ctx, inventory, activePolicy)
+	if err != nil {
+		return nil, err
+	}
+	policyStatus.ProvisioningAllowed = canProvision(activePolicy, effectivePolicy)
+
+	if policyStatus.ConnectionNamespaces, err = r.getConnectionNamespaces(ctx, inventory, activePolicy); err != nil {
+		return nil, err
+	}
+	return policyStatus, nil
+}
+
+// check if provisioning is allowed against an inventory, with the effective policy of the inventory
+func canProvision(activePolicy *v1beta1.DBaaSPolicy, effectivePolicy *v1beta1.DBaaSPolicySpec) bool {
 	if activePolicy == nil {
 		// not an active namespace
 		return false
 	}
-	if inventory.Spec.Policy != nil {
-		if inventory.Spec.Policy.DisableProvisions != nil {
-			return !*inventory.Spec.Policy.DisableProvisions
-		}
-	} else {
-		if activePolicy.Spec.DisableProvisions != nil {
-			return !*activePolicy.Spec.DisableProvisions
-		}
-	}
-	return true
+	return effectivePolicy.DisableProvisions == nil || !*effectivePolicy.DisableProvisions
 }
 
 func (r *DBaaSReconciler) reconcileProviderResource(ctx context.Context, providerName string, DBaaSObject client.Object,
@@ -278,8 +324,12 @@ func (r *DBaaSReconciler) checkInventory(ctx context.Context, inventoryRef v1bet
 	if err != nil {
 		return
 	}
-	activePolicy := getActivePolicy(policyList)
-	provision = canProvision(inventory, activePolicy)
+	activePolicy := v1beta1.GetActivePolicy(policyList.Items)
+	effectivePolicy, err := r.getEffectivePolicy(ctx, inventory, activePolicy)
+	if err != nil {
+		return
+	}
+	provision = canProvision(activePolicy, effectivePolicy)
 
 	validNS, err = r.isValidConnectionNS(ctx, DBaaSObject.GetNamespace(), inventory, activePolicy)
 	if err != nil {
@@ -297,11 +347,27 @@ func (r *DBaaSReconciler) checkInventory(ctx context.Context, inventoryRef v1bet
 			err = fmt.Errorf("inventory %v provisioning is disabled", inventoryRef)
 			logger.Error(err, "Inventory provisioning is disabled", "Inventory", inventory.Name, "Namespace", inventory.Namespace)
 			statusErrorFn(v1beta1.DBaaSInventoryNotProvisionable, v1beta1.MsgInventoryNotProvisionable)
+		} else if violations := checkPolicyRestrictions(inventory, effectivePolicy, DBaaSObject); len(violations) > 0 {
+			err = fmt.Errorf("inventory %v policy is violated: %w", inventoryRef, violations.ToAggregate())
+			logger.Error(err, "Policy is violated", "Inventory", inventory.Name, "Namespace", inventory.Namespace)
+			message := violations.ToAggregate().Error()
+			if revoked, errRevoke := r.enforcePolicy(ctx, inventory, activePolicy, effectivePolicy, DBaaSObject, logger); errRevoke != nil {
+				err = errRevoke
+			} else if revoked {
+				message += ". " + v1beta1.MsgConnectionRevoked
+			}
+			statusErrorFn(v1beta1.DBaaSPolicyViolation, message)
 		} else {
 			return
 		}
 	} else {
-		statusErrorFn(v1beta1.DBaaSInvalidNamespace, v1beta1.MsgInvalidNamespace)
+		message := v1beta1.MsgInvalidNamespace
+		if revoked, errRevoke := r.enforcePolicy(ctx, inventory, activePolicy, effectivePolicy, DBaaSObject, logger); errRevoke != nil {
+			err = errRevoke
+		} else if revoked {
+			message += ". " + v1beta1.MsgConnectionRevoked
+		}
+		statusErrorFn(v1beta1.DBaaSInvalidNamespace, message)
 	}
 
 	if errCond := r.Client.Status().Update(ctx, DBaaSObject); errCond != nil {
@@ -315,12 +381,226 @@ func (r *DBaaSReconciler) checkInventory(ctx context.Context, inventoryRef v1bet
 	return
 }
 
-func (r *DBaaSReconciler) getProviderSpecStatusVersion(provider *v1beta1.DBaaSProvider) *schema.GroupVersion {
-	if provider.GetDBaaSAPIGroupVersion().String() == v1alpha1.GroupVersion.String() &&
-		provider.Name != rdsProvider {
-		return &v1alpha1.GroupVersion
+// checkPolicyRestrictions returns the violations of the effective policy restrictions of the inventory: its provider is
+// checked for all DBaaS objects, and the provisioning parameters for the instances
+func checkPolicyRestrictions(inventory *v1beta1.DBaaSInventory, effectivePolicy *v1beta1.DBaaSPolicySpec, DBaaSObject client.Object) field.ErrorList {
+	restrictions := effectivePolicy.Restrictions
+	if restrictions == nil {
+		return nil
+	}
+	violations := restrictions.ValidateProvider(inventory.Spec.ProviderRef.Name, field.NewPath("spec").Child("providerRef", "name"))
+	if instance, ok := DBaaSObject.(*v1beta1.DBaaSInstance); ok {
+		violations = append(violations, restrictions.ValidateProvisioningParameters(instance.Spec.ProvisioningParameters,
+			field.NewPath("spec").Child("provisioningParameters"))...)
+	}
+	return violations
+}
+
+// updateDBaaSObjectStatus updates the status of a DBaaS object, a conflict is ignored as the object is reconciled again on change
+func (r *DBaaSReconciler) updateDBaaSObjectStatus(ctx context.Context, DBaaSObject client.Object) error {
+	if err := r.Client.Status().Update(ctx, DBaaSObject); err != nil {
+		if errors.IsConflict(err) {
+			ctrl.LoggerFrom(ctx).V(1).Info("DBaaS Object modified", "DBaaS Object", DBaaSObject)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// localRef defaults the namespace of a reference to the namespace of the referencing object, and rejects the
+// references to other namespaces
+func localRef(ref *v1beta1.NamespacedName, namespace string) (*v1beta1.NamespacedName, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	if len(ref.Namespace) > 0 && ref.Namespace != namespace {
+		return nil, fmt.Errorf("reference %s/%s is not in namespace %s", ref.Namespace, ref.Name, namespace)
+	}
+	return &v1beta1.NamespacedName{Name: ref.Name, Namespace: namespace}, nil
+}
+
+// getReferencedInstanceID returns the instance ID of a DBaaSInstance that must use the given inventory
+func (r *DBaaSReconciler) getReferencedInstanceID(ctx context.Context, inventoryRef v1beta1.NamespacedName, instanceRef *v1beta1.NamespacedName) (string, error) {
+	if instanceRef == nil || len(instanceRef.Name) == 0 {
+		return "", fmt.Errorf("database service reference is not properly set")
+	}
+
+	instance := &v1beta1.DBaaSInstance{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      instanceRef.Name,
+		Namespace: instanceRef.Namespace,
+	}, instance); err != nil {
+		return "", fmt.Errorf("cannot read the instance reference")
+	}
+
+	if instance.Spec.InventoryRef.Namespace != inventoryRef.Namespace ||
+		instance.Spec.InventoryRef.Name != inventoryRef.Name {
+		return "", fmt.Errorf("instance and referencing object don't use the same inventory reference")
+	}
+
+	if len(instance.Status.InstanceID) == 0 {
+		return "", fmt.Errorf("instance ID is not available")
+	}
+	return instance.Status.InstanceID, nil
+}
+
+// addDependentsFinalizer adds the finalizer used to handle the dependent objects on deletion
+func (r *DBaaSReconciler) addDependentsFinalizer(ctx context.Context, DBaaSObject client.Object) error {
+	if controllerutil.ContainsFinalizer(DBaaSObject, v1beta1.DBaaSDependentsFinalizer) {
+		return nil
 	}
-	return &v1beta1.GroupVersion
+	controllerutil.AddFinalizer(DBaaSObject, v1beta1.DBaaSDependentsFinalizer)
+	return r.Update(ctx, DBaaSObject)
+}
+
+// reconcileDependentsDeletion applies the deletion policy of a deleted DBaaS object to its dependent objects,
+// and removes the finalizer once no dependent objects are left
+func (r *DBaaSReconciler) reconcileDependentsDeletion(ctx context.Context, DBaaSObject client.Object, deletionPolicy v1beta1.DeletionPolicy,
+	dependents []client.Object, conditions *[]metav1.Condition, logger logr.Logger) error {
+	if !controllerutil.ContainsFinalizer(DBaaSObject, v1beta1.DBaaSDependentsFinalizer) {
+		return nil
+	}
+
+	if deletionPolicy != v1beta1.DeletionPolicyOrphan && len(dependents) > 0 {
+		reason, message := v1beta1.DBaaSDependentsExist, v1beta1.MsgDeletionBlocked
+		if deletionPolicy == v1beta1.DeletionPolicyCascade {
+			reason, message = v1beta1.DBaaSDeletingDependents, v1beta1.MsgDeletingDependents
+			for _, dependent := range dependents {
+				if dependent.GetDeletionTimestamp() != nil {
+					continue
+				}
+				if err := r.Delete(ctx, dependent); err != nil && !errors.IsNotFound(err) {
+					return err
+				}
+			}
+		}
+		names := make([]string, 0, len(dependents))
+		for _, dependent := range dependents {
+			kind := ""
+			if gvk, err := apiutil.GVKForObject(dependent, r.Scheme); err == nil {
+				kind = gvk.Kind
+			}
+			names = append(names, fmt.Sprintf("%s %s/%s", kind, dependent.GetNamespace(), dependent.GetName()))
+		}
+		sort.Strings(names)
+		logger.Info("Waiting for the dependent objects to be deleted", "deletionPolicy", deletionPolicy, "dependents", names)
+		apimeta.SetStatusCondition(conditions, metav1.Condition{
+			Type:    v1beta1.DBaaSDeletionBlockedType,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: fmt.Sprintf("%s: %s", message, strings.Join(names, ", ")),
+		})
+		return r.updateDBaaSObjectStatus(ctx, DBaaSObject)
+	}
+
+	logger.Info("Removing the finalizer", "deletionPolicy", deletionPolicy, "dependents", len(dependents))
+	controllerutil.RemoveFinalizer(DBaaSObject, v1beta1.DBaaSDependentsFinalizer)
+	return r.Update(ctx, DBaaSObject)
+}
+
+// getInventoryDependents returns the connections and instances that reference the inventory
+func (r *DBaaSReconciler) getInventoryDependents(ctx context.Context, inventory *v1beta1.DBaaSInventory) ([]client.Object, error) {
+	var dependents []client.Object
+	key := client.MatchingFields{v1beta1.InventoryRefIndex: client.ObjectKeyFromObject(inventory).String()}
+
+	connections := &v1beta1.DBaaSConnectionList{}
+	if err := r.List(ctx, connections, key); err != nil {
+		return nil, err
+	}
+	for i := range connections.Items {
+		dependents = append(dependents, &connections.Items[i])
+	}
+
+	instances := &v1beta1.DBaaSInstanceList{}
+	if err := r.List(ctx, instances, key); err != nil {
+		return nil, err
+	}
+	for i := range instances.Items {
+		dependents = append(dependents, &instances.Items[i])
+	}
+	return dependents, nil
+}
+
+// getInstanceDependents returns the connections that reference the instance
+func (r *DBaaSReconciler) getInstanceDependents(ctx context.Context, instance *v1beta1.DBaaSInstance) ([]client.Object, error) {
+	var dependents []client.Object
+	connections := &v1beta1.DBaaSConnectionList{}
+	if err := r.List(ctx, connections, client.MatchingFields{v1beta1.ConnectionInstanceIndex: client.ObjectKeyFromObject(instance).String()}); err != nil {
+		return nil, err
+	}
+	for i := range connections.Items {
+		dependents = append(dependents, &connections.Items[i])
+	}
+	return dependents, nil
+}
+
+// removeDependentsFinalizers removes the dependents finalizer from all the inventories and instances,
+// so that they can still be deleted once the operator is uninstalled
+func (r *DBaaSReconciler) removeDependentsFinalizers(ctx context.Context) error {
+	inventories := &v1beta1.DBaaSInventoryList{}
+	if err := r.List(ctx, inventories); err != nil {
+		return err
+	}
+	instances := &v1beta1.DBaaSInstanceList{}
+	if err := r.List(ctx, instances); err != nil {
+		return err
+	}
+	objects := make([]client.Object, 0, len(inventories.Items)+len(instances.Items))
+	for i := range inventories.Items {
+		objects = append(objects, &inventories.Items[i])
+	}
+	for i := range instances.Items {
+		objects = append(objects, &instances.Items[i])
+	}
+	for _, obj := range objects {
+		if !controllerutil.RemoveFinalizer(obj, v1beta1.DBaaSDependentsFinalizer) {
+			continue
+		}
+		if err := r.Update(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteEventPredicate only passes delete events, to requeue objects waiting for their dependent objects to be deleted
+var deleteEventPredicate = predicate.Funcs{
+	CreateFunc: func(createEvent event.CreateEvent) bool {
+		return false
+	},
+	UpdateFunc: func(updateEvent event.UpdateEvent) bool {
+		return false
+	},
+	DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+		return true
+	},
+	GenericFunc: func(genericEvent event.GenericEvent) bool {
+		return false
+	},
+}
+
+// inventoryRefName returns the name of the inventory referenced by an object in the given namespace
+func inventoryRefName(ref v1beta1.NamespacedName, namespace string) types.NamespacedName {
+	if len(ref.Namespace) > 0 {
+		namespace = ref.Namespace
+	}
+	return types.NamespacedName{Name: ref.Name, Namespace: namespace}
+}
+
+// getDatabaseServiceRef returns the instance referenced by a connection, or nil if it does not reference an instance
+func getDatabaseServiceRef(connection *v1beta1.DBaaSConnection) *types.NamespacedName {
+	if connection.Spec.DatabaseServiceRef == nil || len(connection.Spec.DatabaseServiceRef.Name) == 0 || connection.Spec.DatabaseServiceType != nil {
+		return nil
+	}
+	ref := &types.NamespacedName{
+		Name:      connection.Spec.DatabaseServiceRef.Name,
+		Namespace: connection.Spec.DatabaseServiceRef.Namespace,
+	}
+	if len(ref.Namespace) == 0 {
+		ref.Namespace = connection.Namespace
+	}
+	return ref
 }
 
 func (r *DBaaSReconciler) checkCredsRefLabel(ctx context.Context, inventory v1beta1.DBaaSInventory) error {
@@ -334,12 +614,8 @@ func (r *DBaaSReconciler) checkCredsRefLabel(ctx context.Context, inventory v1be
 		}
 
 		secretPatch := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}}}
-		if strings.Contains(inventory.Spec.ProviderRef.Name, "mongodb") {
-			if secret.GetLabels()[v1beta1.TypeLabelKeyMongo] != v1beta1.TypeLabelValue {
-				secretPatch.Labels[v1beta1.TypeLabelKeyMongo] = v1beta1.TypeLabelValue
-			}
-		} else if secret.GetLabels()[v1beta1.TypeLabelKey] != v1beta1.TypeLabelValue {
-			secretPatch.Labels[v1beta1.TypeLabelKey] = v1beta1.TypeLabelValue
+		if labelKey := v1beta1.GetCredentialsLabelKey(inventory.Spec.ProviderRef.Name); secret.GetLabels()[labelKey] != v1beta1.TypeLabelValue {
+			secretPatch.Labels[labelKey] = v1beta1.TypeLabelValue
 		}
 
 		if len(secretPatch.Labels) > 0 {
@@ -25,6 +25,7 @@ import (
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/providerapi"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -181,32 +182,82 @@ var _ = Describe("Provider object MutateFn", func() {
 	})
 })
 
+var _ = Describe("Provider object MutateFn with a credentials rotation request", func() {
+	It("should only relay the rotation request to the provider connections", func() {
+		annotations := map[string]string{v1beta1.RotateCredentialsAnnotation: "request-1"}
+		connection := &v1beta1.DBaaSConnection{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+		providerConnection := &unstructured.Unstructured{}
+		providerConnection.SetUnstructuredContent(make(map[string]interface{}, 1))
+		Expect(dRec.providerObjectMutateFn(connection, providerConnection, &v1beta1.DBaaSConnectionSpec{})()).Should(Succeed())
+		Expect(providerConnection.GetAnnotations()).Should(HaveKeyWithValue(v1beta1.RotateCredentialsAnnotation, "request-1"))
+
+		instance := &v1beta1.DBaaSInstance{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+		providerInstance := &unstructured.Unstructured{}
+		providerInstance.SetUnstructuredContent(make(map[string]interface{}, 1))
+		Expect(dRec.providerObjectMutateFn(instance, providerInstance, &v1beta1.DBaaSInstanceSpec{})()).Should(Succeed())
+		Expect(providerInstance.GetAnnotations()).ShouldNot(HaveKey(v1beta1.RotateCredentialsAnnotation))
+	})
+})
+
 var _ = Describe("Watch DBaaS provider Object", func() {
+	gvk := schema.GroupVersionKind{
+		Group:   v1alpha1.GroupVersion.Group,
+		Version: v1alpha1.GroupVersion.Version,
+		Kind:    "test-kind",
+	}
+	source := &unstructured.Unstructured{}
+	source.SetGroupVersionKind(gvk)
+	owner := &v1beta1.DBaaSInventory{}
+
 	It("should invoke controller watch with correctly input", func() {
-		source := &unstructured.Unstructured{}
-		source.SetGroupVersionKind(schema.GroupVersionKind{
-			Group:   v1alpha1.GroupVersion.Group,
-			Version: v1alpha1.GroupVersion.Version,
-			Kind:    "test-kind",
-		})
-		owner := &v1beta1.DBaaSInventory{}
 		spyController := newSpyController(nil)
+		watches := newProviderWatches(cfg, dRec.Scheme, dRec.RESTMapper())
 
-		err := dRec.watchDBaaSProviderObject(spyController, owner, "test-kind", &v1alpha1.GroupVersion)
+		started, err := watches.watch("test-provider", spyController, owner, gvk)
 		Expect(err).NotTo(HaveOccurred())
+		Expect(started).Should(BeTrue())
 		Eventually(func() bool {
 			return spyController.watched(&watchable{
 				source: source,
 				owner:  owner,
 			})
 		}, timeout).Should(BeTrue())
+		watches.release("test-provider")
+	})
+
+	It("should watch a kind once, until no provider declares it", func() {
+		spyController := newSpyController(nil)
+		watches := newProviderWatches(cfg, dRec.Scheme, dRec.RESTMapper())
+
+		for _, provider := range []string{"test-provider-1", "test-provider-2", "test-provider-1"} {
+			_, err := watches.watch(provider, spyController, owner, gvk)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Eventually(func() int {
+			return spyController.count(&watchable{source: source, owner: owner})
+		}, timeout).Should(Equal(1))
+		Consistently(func() int {
+			return spyController.count(&watchable{source: source, owner: owner})
+		}).Should(Equal(1))
+
+		watches.release("test-provider-1")
+		Expect(watches.isWatched(spyController, gvk)).Should(BeTrue())
+		watches.release("test-provider-2", providerWatchKey{ctrl: spyController, gvk: gvk})
+		Expect(watches.isWatched(spyController, gvk)).Should(BeTrue())
+		watches.release("test-provider-2")
+		Expect(watches.isWatched(spyController, gvk)).Should(BeFalse())
+
+		started, err := watches.watch("test-provider-1", spyController, owner, gvk)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(started).Should(BeTrue())
+		watches.release("test-provider-1")
 	})
 })
 
 var _ = Describe("Get Provider Spec Status Version", func() {
 	DescribeTable("it should return the expected groupversion",
 		func(provider *v1beta1.DBaaSProvider, groupVersion *schema.GroupVersion) {
-			gv := dRec.getProviderSpecStatusVersion(provider)
+			gv := providerapi.GetSpecStatusVersion(provider)
 			Expect(gv.String()).Should(Equal(groupVersion.String()))
 		},
 
@@ -214,7 +265,7 @@ var _ = Describe("Get Provider Spec Status Version", func() {
 		Entry("crunchy provider", crunchyProvider, &v1beta1.GroupVersion),
 		Entry("rds provider", &v1beta1.DBaaSProvider{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: rdsProvider,
+				Name: "rds-registration",
 			},
 			Spec: v1beta1.DBaaSProviderSpec{
 				GroupVersion: v1alpha1.GroupVersion.String(),
@@ -282,7 +333,7 @@ var _ = Describe("list policies by inventory namespace", func() {
 				}
 			}
 
-			activePolicy := getActivePolicy(policyList)
+			activePolicy := v1beta1.GetActivePolicy(policyList.Items)
 			Expect(activePolicy).Should(Not(BeNil()))
 			Expect(activePolicy.Name).Should(Equal(policy1.Name))
 
@@ -296,17 +347,17 @@ var _ = Describe("list policies by inventory namespace", func() {
 			Expect(isOwner(&policy1, &rqList.Items[0], dRec.Scheme)).Should(BeTrue())
 
 			inventory := &v1beta1.DBaaSInventory{ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name}}
-			Expect(canProvision(inventory, activePolicy)).Should(BeTrue())
+			Expect(canProvision(activePolicy, v1beta1.GetEffectivePolicy(nil, activePolicy, inventory))).Should(BeTrue())
 			activePolicy.Spec.DisableProvisions = &isTrue
-			Expect(canProvision(inventory, activePolicy)).Should(BeFalse())
+			Expect(canProvision(activePolicy, v1beta1.GetEffectivePolicy(nil, activePolicy, inventory))).Should(BeFalse())
 
 			// override policy setting
 			isFalse := false
 			inventory.Spec.Policy = &v1beta1.DBaaSInventoryPolicy{DisableProvisions: &isFalse}
-			Expect(canProvision(inventory, activePolicy)).Should(BeTrue())
+			Expect(canProvision(activePolicy, v1beta1.GetEffectivePolicy(nil, activePolicy, inventory))).Should(BeTrue())
 
 			// check nil policy
-			Expect(canProvision(inventory, nil)).Should(BeFalse())
+			Expect(canProvision(nil, v1beta1.GetEffectivePolicy(nil, nil, inventory))).Should(BeFalse())
 		})
 
 		It("should, upon deletion, make another policy active", func() {
@@ -341,12 +392,12 @@ var _ = Describe("list policies by inventory namespace", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(policyList.Items).Should(HaveLen(1))
 
-			activePolicy := getActivePolicy(policyList)
+			activePolicy := v1beta1.GetActivePolicy(policyList.Items)
 			Expect(activePolicy).Should(Not(BeNil()))
 			Expect(activePolicy.Name).Should(Equal(policy2.Name))
 
 			inventory := &v1beta1.DBaaSInventory{ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name}}
-			Expect(canProvision(inventory, activePolicy)).Should(BeFalse())
+			Expect(canProvision(activePolicy, v1beta1.GetEffectivePolicy(nil, activePolicy, inventory))).Should(BeFalse())
 		})
 	})
 })
@@ -627,6 +678,246 @@ var _ = Describe("Reconcile Provider Resource", func() {
 	})
 })
 
+var _ = Describe("Check policy restrictions", func() {
+	maxStorage := int32(10)
+	policy := &v1beta1.DBaaSPolicy{
+		Spec: v1beta1.DBaaSPolicySpec{
+			DBaaSInventoryPolicy: v1beta1.DBaaSInventoryPolicy{
+				Restrictions: &v1beta1.DBaaSPolicyRestrictions{
+					Providers:     []string{testProviderName},
+					Regions:       []string{"us-east-1", "us-east-2"},
+					MaxStorageGib: &maxStorage,
+				},
+			},
+		},
+	}
+
+	DescribeTable("checking the restrictions of an inventory",
+		func(providerName string, inventoryRestrictions *v1beta1.DBaaSPolicyRestrictions, DBaaSObject client.Object, expectedErr string) {
+			inventory := &v1beta1.DBaaSInventory{
+				Spec: v1beta1.DBaaSOperatorInventorySpec{
+					ProviderRef: v1beta1.NamespacedName{Name: providerName},
+				},
+			}
+			if inventoryRestrictions != nil {
+				inventory.Spec.Policy = &v1beta1.DBaaSInventoryPolicy{Restrictions: inventoryRestrictions}
+			}
+			violations := checkPolicyRestrictions(inventory, v1beta1.GetEffectivePolicy(nil, policy, inventory), DBaaSObject)
+			if len(expectedErr) == 0 {
+				Expect(violations).Should(BeEmpty())
+			} else {
+				Expect(violations.ToAggregate()).Should(MatchError(expectedErr))
+			}
+		},
+		Entry("allow an allowed provider", testProviderName, nil, &v1beta1.DBaaSConnection{}, ""),
+		Entry("not allow another provider", "other-provider", nil, &v1beta1.DBaaSConnection{},
+			"spec.providerRef.name: Forbidden: provider other-provider is not allowed by policy, allowed providers: "+testProviderName),
+		Entry("allow the providers of the inventory restrictions", "other-provider",
+			&v1beta1.DBaaSPolicyRestrictions{Providers: []string{"other-provider"}}, &v1beta1.DBaaSConnection{}, ""),
+		Entry("allow instance parameters within the restrictions", testProviderName, nil,
+			&v1beta1.DBaaSInstance{Spec: v1beta1.DBaaSInstanceSpec{ProvisioningParameters: map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningRegions:    "us-east-1,us-east-2",
+				v1beta1.ProvisioningStorageGib: "10",
+			}}}, ""),
+		Entry("not allow instance parameters outside the restrictions", testProviderName, nil,
+			&v1beta1.DBaaSInstance{Spec: v1beta1.DBaaSInstanceSpec{ProvisioningParameters: map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningRegions:    "us-east-1,eu-west-1",
+				v1beta1.ProvisioningStorageGib: "20",
+			}}},
+			"[spec.provisioningParameters[regions]: Forbidden: value eu-west-1 is not allowed by policy, allowed values: us-east-1, us-east-2, "+
+				"spec.provisioningParameters[storageGib]: Forbidden: value 20 exceeds the maximum of 10 allowed by policy]"),
+	)
+})
+
+var _ = Describe("Enforce policy", func() {
+	BeforeEach(assertResourceCreationIfNotExists(crunchyProvider))
+
+	inventory := &v1beta1.DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "enforce-policy-inventory",
+			Namespace: testNamespace,
+		},
+		Spec: v1beta1.DBaaSOperatorInventorySpec{
+			ProviderRef: v1beta1.NamespacedName{
+				Name: v1beta1.CrunchyBridgeRegistration,
+			},
+		},
+	}
+	DescribeTable("should revoke the provider connection only when the policy is enforced",
+		func(mode *v1beta1.PolicyEnforcementMode, object client.Object, expected bool) {
+			effectivePolicy := &v1beta1.DBaaSPolicySpec{
+				DBaaSInventoryPolicy: v1beta1.DBaaSInventoryPolicy{EnforcementMode: mode},
+			}
+			revoked, err := dRec.enforcePolicy(ctx, inventory, &v1beta1.DBaaSPolicy{}, effectivePolicy, object, ctrl.LoggerFrom(ctx))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).Should(Equal(expected))
+			if connection, ok := object.(*v1beta1.DBaaSConnection); ok {
+				Expect(connection.Status.CredentialsRef == nil).Should(Equal(expected))
+			}
+		},
+		Entry("not by default", nil, enforcePolicyConnection(), false),
+		Entry("not in warn mode", policyEnforcementMode(v1beta1.PolicyEnforcementWarn), enforcePolicyConnection(), false),
+		Entry("in enforce mode", policyEnforcementMode(v1beta1.PolicyEnforcementEnforce), enforcePolicyConnection(), true),
+		Entry("not for instances", policyEnforcementMode(v1beta1.PolicyEnforcementEnforce), &v1beta1.DBaaSInstance{}, false),
+	)
+
+	It("should not revoke the provider connection while the policy of the namespace is replaced", func() {
+		effectivePolicy := &v1beta1.DBaaSPolicySpec{
+			DBaaSInventoryPolicy: v1beta1.DBaaSInventoryPolicy{EnforcementMode: policyEnforcementMode(v1beta1.PolicyEnforcementEnforce)},
+		}
+		connection := enforcePolicyConnection()
+		connection.Namespace = "default"
+		revoked, err := dRec.enforcePolicy(ctx, inventory, nil, effectivePolicy, connection, ctrl.LoggerFrom(ctx))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revoked).Should(BeFalse())
+		Expect(connection.Status.CredentialsRef).ShouldNot(BeNil())
+	})
+})
+
+func policyEnforcementMode(mode v1beta1.PolicyEnforcementMode) *v1beta1.PolicyEnforcementMode {
+	return &mode
+}
+
+func enforcePolicyConnection() *v1beta1.DBaaSConnection {
+	return &v1beta1.DBaaSConnection{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "enforce-policy-connection",
+			Namespace: testNamespace,
+		},
+		Status: v1beta1.DBaaSConnectionStatus{
+			CredentialsRef: &corev1.LocalObjectReference{Name: "enforce-policy-credentials"},
+		},
+	}
+}
+
+var _ = Describe("Get connection namespaces", func() {
+	inventory := &v1beta1.DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "connection-namespaces-inventory",
+			Namespace: testNamespace,
+		},
+	}
+	DescribeTable("should return the namespaces allowed to connect",
+		func(inventoryConnections *v1beta1.DBaaSConnectionPolicy, policyConnections v1beta1.DBaaSConnectionPolicy, expected []string) {
+			inv := inventory.DeepCopy()
+			if inventoryConnections != nil {
+				inv.Spec.Policy = &v1beta1.DBaaSInventoryPolicy{Connections: *inventoryConnections}
+			}
+			policy := &v1beta1.DBaaSPolicy{Spec: v1beta1.DBaaSPolicySpec{
+				DBaaSInventoryPolicy: v1beta1.DBaaSInventoryPolicy{Connections: policyConnections},
+			}}
+			namespaces, err := dRec.getConnectionNamespaces(ctx, inv, policy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespaces).Should(Equal(expected))
+		},
+		Entry("only the inventory namespace by default", nil, v1beta1.DBaaSConnectionPolicy{}, []string{testNamespace}),
+		Entry("all namespaces by policy", nil, v1beta1.DBaaSConnectionPolicy{Namespaces: &[]string{"*"}}, []string{"*"}),
+		Entry("namespaces by policy", nil, v1beta1.DBaaSConnectionPolicy{Namespaces: &[]string{"test-ns", testNamespace}},
+			[]string{testNamespace, "test-ns"}),
+		Entry("namespaces by inventory overriding the policy", &v1beta1.DBaaSConnectionPolicy{Namespaces: &[]string{"test-ns"}},
+			v1beta1.DBaaSConnectionPolicy{Namespaces: &[]string{"*"}}, []string{testNamespace, "test-ns"}),
+		Entry("namespaces selected by labels", nil, v1beta1.DBaaSConnectionPolicy{NsSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"kubernetes.io/metadata.name": "default"},
+		}}, []string{testNamespace, "default"}),
+	)
+})
+
+var _ = Describe("Get effective policy", func() {
+	isTrue := true
+	isFalse := false
+	maxNodes := int32(5)
+	clusterMaxNodes := int32(3)
+	clusterPolicies := []v1beta1.DBaaSClusterPolicy{
+		{
+			Spec: v1beta1.DBaaSClusterPolicySpec{
+				RequireApproval: &isTrue,
+				Restrictions: &v1beta1.DBaaSPolicyRestrictions{
+					Plans:    []string{v1beta1.ProvisioningPlanFreeTrial, v1beta1.ProvisioningPlanServerless},
+					MaxNodes: &clusterMaxNodes,
+				},
+			},
+		},
+	}
+	policy := &v1beta1.DBaaSPolicy{
+		Spec: v1beta1.DBaaSPolicySpec{
+			DBaaSInventoryPolicy: v1beta1.DBaaSInventoryPolicy{
+				DisableProvisions: &isFalse,
+				RequireApproval:   &isFalse,
+				Restrictions: &v1beta1.DBaaSPolicyRestrictions{
+					Plans:    []string{v1beta1.ProvisioningPlanServerless, v1beta1.ProvisioningPlanDedicated},
+					MaxNodes: &maxNodes,
+				},
+			},
+		},
+	}
+
+	It("should limit the namespace policy to the cluster policy ceilings", func() {
+		effectivePolicy := v1beta1.GetEffectivePolicy(clusterPolicies, policy, nil)
+		Expect(*effectivePolicy.DisableProvisions).Should(BeFalse())
+		Expect(*effectivePolicy.RequireApproval).Should(BeTrue())
+		Expect(effectivePolicy.Restrictions.Plans).Should(Equal([]string{v1beta1.ProvisioningPlanServerless}))
+		Expect(*effectivePolicy.Restrictions.MaxNodes).Should(Equal(clusterMaxNodes))
+	})
+
+	It("should limit the inventory overrides to the cluster policy ceilings", func() {
+		inventory := &v1beta1.DBaaSInventory{
+			Spec: v1beta1.DBaaSOperatorInventorySpec{
+				Policy: &v1beta1.DBaaSInventoryPolicy{
+					DisableProvisions: &isTrue,
+					Restrictions: &v1beta1.DBaaSPolicyRestrictions{
+						Plans: []string{v1beta1.ProvisioningPlanFreeTrial},
+					},
+				},
+			},
+		}
+		effectivePolicy := v1beta1.GetEffectivePolicy(clusterPolicies, policy, inventory)
+		Expect(canProvision(policy, effectivePolicy)).Should(BeFalse())
+		Expect(requiresApproval(effectivePolicy)).Should(BeTrue())
+		Expect(effectivePolicy.Restrictions.Plans).Should(Equal([]string{v1beta1.ProvisioningPlanFreeTrial}))
+		Expect(*effectivePolicy.Restrictions.MaxNodes).Should(Equal(clusterMaxNodes))
+	})
+
+	It("should not allow provisioning when a cluster policy disables it", func() {
+		clusterPolicies := []v1beta1.DBaaSClusterPolicy{{Spec: v1beta1.DBaaSClusterPolicySpec{DisableProvisions: &isTrue}}}
+		Expect(canProvision(policy, v1beta1.GetEffectivePolicy(clusterPolicies, policy, nil))).Should(BeFalse())
+	})
+
+	It("should limit the connection namespaces to the cluster policy ceilings", func() {
+		clusterPolicies := []v1beta1.DBaaSClusterPolicy{{Spec: v1beta1.DBaaSClusterPolicySpec{
+			Connections: &v1beta1.DBaaSConnectionPolicy{Namespaces: &[]string{"test-ns", "other-ns"}},
+		}}}
+		policy := &v1beta1.DBaaSPolicy{Spec: v1beta1.DBaaSPolicySpec{DBaaSInventoryPolicy: v1beta1.DBaaSInventoryPolicy{
+			Connections: v1beta1.DBaaSConnectionPolicy{Namespaces: &[]string{"test-ns", "denied-ns"}},
+		}}}
+		Expect(*v1beta1.GetEffectivePolicy(clusterPolicies, policy, nil).Connections.Namespaces).Should(Equal([]string{"test-ns"}))
+
+		policy.Spec.Connections.Namespaces = &[]string{"*"}
+		Expect(*v1beta1.GetEffectivePolicy(clusterPolicies, policy, nil).Connections.Namespaces).Should(Equal([]string{"test-ns", "other-ns"}))
+	})
+
+	It("should only allow the connection namespaces selected by the cluster policy ceilings", func() {
+		clusterPolicies := []v1beta1.DBaaSClusterPolicy{{Spec: v1beta1.DBaaSClusterPolicySpec{
+			Connections: &v1beta1.DBaaSConnectionPolicy{
+				Namespaces: &[]string{"test-ns"},
+				NsSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"dbaas": "allowed"}},
+			},
+		}}}
+		Expect(v1beta1.LimitsConnectionNamespaces(clusterPolicies)).Should(BeTrue())
+		Expect(v1beta1.IsConnectionNamespaceAllowed(clusterPolicies, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ns"},
+		})).Should(BeTrue())
+		Expect(v1beta1.IsConnectionNamespaceAllowed(clusterPolicies, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "labeled-ns", Labels: map[string]string{"dbaas": "allowed"}},
+		})).Should(BeTrue())
+		Expect(v1beta1.IsConnectionNamespaceAllowed(clusterPolicies, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-ns"},
+		})).Should(BeFalse())
+		Expect(v1beta1.LimitsConnectionNamespaces([]v1beta1.DBaaSClusterPolicy{{Spec: v1beta1.DBaaSClusterPolicySpec{
+			Connections: &v1beta1.DBaaSConnectionPolicy{Namespaces: &[]string{"*"}},
+		}}})).Should(BeFalse())
+	})
+})
+
 func getLastTransitionTimeForTest() time.Time {
 	lastTransitionTime, err := time.Parse(time.RFC3339, "2021-06-30T22:17:55-04:00")
 	Expect(err).NotTo(HaveOccurred())
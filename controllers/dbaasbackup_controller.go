@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/providerapi"
+)
+
+// DBaaSBackupReconciler reconciles a DBaaSBackup object
+type DBaaSBackupReconciler struct {
+	*DBaaSReconciler
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.13.1/pkg/reconcile
+func (r *DBaaSBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var backup v1beta1.DBaaSBackup
+	if err := r.Get(ctx, req.NamespacedName, &backup); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, child objects getting GC'd, no requeue
+			logger.V(1).Info("DBaaS Backup resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Backup for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	setStatusCondition := func(reason string, message string) {
+		cond := metav1.Condition{
+			Type:    v1beta1.DBaaSBackupReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		}
+		apimeta.SetStatusCondition(&backup.Status.Conditions, cond)
+	}
+
+	inventory, validNS, _, err := r.checkInventory(ctx, backup.Spec.InventoryRef, &backup, setStatusCondition, logger)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if !validNS {
+		return ctrl.Result{}, nil
+	}
+
+	provider, err := r.getDBaaSProvider(ctx, inventory.Spec.ProviderRef.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(provider.Spec.BackupKind) == 0 {
+		logger.Info("DBaaS Provider does not support backups", "DBaaS Provider", provider.Name)
+		setStatusCondition(v1beta1.DBaaSProviderKindNotSupported, v1beta1.MsgBackupNotSupported)
+		return ctrl.Result{}, r.updateDBaaSObjectStatus(ctx, &backup)
+	}
+
+	spec := backup.Spec.DeepCopy()
+	if len(spec.DatabaseServiceID) == 0 {
+		instanceRef, err := localRef(spec.DatabaseServiceRef, backup.Namespace)
+		if err == nil {
+			spec.DatabaseServiceID, err = r.getReferencedInstanceID(ctx, spec.InventoryRef, instanceRef)
+		}
+		if err != nil {
+			logger.Error(err, "Cannot read the database service reference")
+			setStatusCondition(v1beta1.DBaaSServiceNotAvailable, err.Error())
+			if errCond := r.updateDBaaSObjectStatus(ctx, &backup); errCond != nil {
+				logger.Error(errCond, "Error updating the DBaaS Backup status")
+			}
+			return ctrl.Result{}, err
+		}
+		spec.DatabaseServiceRef = nil
+	}
+
+	converter, err := providerapi.ForProvider(provider)
+	if err != nil {
+		logger.Error(err, "Error converting the DBaaS Backup for the provider")
+		return ctrl.Result{}, err
+	}
+	providerSpec, err := converter.BackupSpec(spec)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return r.reconcileProviderResource(ctx,
+		inventory.Spec.ProviderRef.Name,
+		&backup,
+		func(provider *v1beta1.DBaaSProvider) string {
+			return provider.Spec.BackupKind
+		},
+		func() interface{} {
+			return providerSpec
+		},
+		converter.NewBackup,
+		func(i interface{}) metav1.Condition {
+			return mergeBackupStatus(&backup, converter.ConvertBackup(i))
+		},
+		func() *[]metav1.Condition {
+			return &backup.Status.Conditions
+		},
+		v1beta1.DBaaSBackupReadyType,
+		logger,
+	)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSBackupReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.DBaaSBackup{}).
+		WithOptions(
+			controller.Options{MaxConcurrentReconciles: 2},
+		).
+		Build(r)
+}
+
+// mergeBackupStatus: merge the status from DBaaSProviderBackup into the current DBaaSBackup status
+func mergeBackupStatus(backup *v1beta1.DBaaSBackup, providerBackup *v1beta1.DBaaSProviderBackup) metav1.Condition {
+	providerBackup.Status.DeepCopyInto(&backup.Status)
+	if len(backup.Status.Phase) == 0 {
+		backup.Status.Phase = v1beta1.BackupPhaseUnknown
+	}
+	// Update backup status condition (type: DBaaSBackupReadyType) based on the provider status
+	specSync := apimeta.FindStatusCondition(providerBackup.Status.Conditions, v1beta1.DBaaSBackupProviderSyncType)
+	if specSync != nil && specSync.Status == metav1.ConditionTrue {
+		return metav1.Condition{
+			Type:    v1beta1.DBaaSBackupReadyType,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1beta1.Ready,
+			Message: v1beta1.MsgProviderCRStatusSyncDone,
+		}
+	}
+	return metav1.Condition{
+		Type:    v1beta1.DBaaSBackupReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1beta1.ProviderReconcileInprogress,
+		Message: v1beta1.MsgProviderCRReconcileInProgress,
+	}
+}
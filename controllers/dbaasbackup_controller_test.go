@@ -0,0 +1,172 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+var _ = Describe("DBaaSBackup controller", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(crunchyProvider))
+	BeforeEach(assertResourceCreationIfNotExists(backupProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1beta1.Ready))
+
+	lastTransitionTime := getLastTransitionTimeForTest()
+	providerInventoryStatus := &v1beta1.DBaaSInventoryStatus{
+		DatabaseServices: []v1beta1.DatabaseService{
+			{
+				ServiceID:   "testInstanceID",
+				ServiceName: "testInstance",
+			},
+		},
+		Conditions: []metav1.Condition{
+			{
+				Type:               "SpecSynced",
+				Status:             metav1.ConditionTrue,
+				Reason:             "SyncOK",
+				LastTransitionTime: metav1.Time{Time: lastTransitionTime},
+			},
+		},
+	}
+
+	Context("after creating DBaaSBackup for a provider without backup support", func() {
+		inventoryName := "test-backup-inventory-not-supported"
+		createdDBaaSInventory := &v1beta1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      inventoryName,
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1beta1.NamespacedName{
+					Name: testProviderName,
+				},
+				DBaaSInventorySpec: v1beta1.DBaaSInventorySpec{
+					CredentialsRef: &v1beta1.LocalObjectReference{
+						Name: testSecret.Name,
+					},
+				},
+			},
+		}
+		createdDBaaSBackup := &v1beta1.DBaaSBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-backup-not-supported",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSBackupSpec{
+				InventoryRef: v1beta1.NamespacedName{
+					Name:      inventoryName,
+					Namespace: testNamespace,
+				},
+				DatabaseServiceID: "testInstanceID",
+			},
+		}
+
+		BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, crunchyProvider.GetDBaaSAPIGroupVersion(), metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
+		BeforeEach(assertResourceCreation(createdDBaaSBackup))
+		AfterEach(assertResourceDeletion(createdDBaaSBackup))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+		It("should report that backups are not supported", assertDBaaSResourceStatusUpdated(createdDBaaSBackup, metav1.ConditionFalse, v1beta1.DBaaSProviderKindNotSupported))
+	})
+
+	Context("after creating DBaaSBackup for a provider with backup support", func() {
+		inventoryName := "test-backup-inventory"
+		createdDBaaSInventory := &v1beta1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      inventoryName,
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1beta1.NamespacedName{
+					Name: testBackupProviderName,
+				},
+				DBaaSInventorySpec: v1beta1.DBaaSInventorySpec{
+					CredentialsRef: &v1beta1.LocalObjectReference{
+						Name: testSecret.Name,
+					},
+				},
+			},
+		}
+		DBaaSBackupSpec := &v1beta1.DBaaSBackupSpec{
+			InventoryRef: v1beta1.NamespacedName{
+				Name:      inventoryName,
+				Namespace: testNamespace,
+			},
+			DatabaseServiceID: "testInstanceID",
+			Schedule:          "0 2 * * *",
+		}
+		createdDBaaSBackup := &v1beta1.DBaaSBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-backup",
+				Namespace: testNamespace,
+			},
+			Spec: *DBaaSBackupSpec,
+		}
+
+		BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, backupProvider.GetDBaaSAPIGroupVersion(), metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
+		BeforeEach(assertResourceCreation(createdDBaaSBackup))
+		AfterEach(assertResourceDeletion(createdDBaaSBackup))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+		It("should create a provider backup", assertProviderResourceCreated(createdDBaaSBackup, backupProvider.GetDBaaSAPIGroupVersion(), testBackupKind, DBaaSBackupSpec))
+	})
+})
+
+var _ = Describe("Merge DBaaSBackup status", func() {
+	It("should copy the provider backup status and set the ready condition", func() {
+		size := resource.MustParse("10Gi")
+		completionTime := metav1.Now()
+		backup := &v1beta1.DBaaSBackup{}
+		providerBackup := &v1beta1.DBaaSProviderBackup{
+			Status: v1beta1.DBaaSBackupStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   v1beta1.DBaaSBackupProviderSyncType,
+						Status: metav1.ConditionTrue,
+						Reason: "SyncOK",
+					},
+				},
+				BackupID:       "test-backup-id",
+				Phase:          v1beta1.BackupPhaseCompleted,
+				Size:           &size,
+				CompletionTime: &completionTime,
+			},
+		}
+
+		cond := mergeBackupStatus(backup, providerBackup)
+		Expect(cond.Type).Should(Equal(v1beta1.DBaaSBackupReadyType))
+		Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(backup.Status.BackupID).Should(Equal("test-backup-id"))
+		Expect(backup.Status.Phase).Should(Equal(v1beta1.BackupPhaseCompleted))
+		Expect(backup.Status.Size.Equal(size)).Should(BeTrue())
+		Expect(backup.Status.CompletionTime).Should(Equal(&completionTime))
+	})
+
+	It("should default the phase when the provider did not set it", func() {
+		backup := &v1beta1.DBaaSBackup{}
+		cond := mergeBackupStatus(backup, &v1beta1.DBaaSProviderBackup{})
+		Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).Should(Equal(v1beta1.ProviderReconcileInprogress))
+		Expect(backup.Status.Phase).Should(Equal(v1beta1.BackupPhaseUnknown))
+	})
+})
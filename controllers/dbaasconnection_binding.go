@@ -0,0 +1,278 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// Well-known entries of the servicebinding.io specification
+const (
+	bindingType     = "type"
+	bindingProvider = "provider"
+	bindingHost     = "host"
+	bindingPort     = "port"
+	bindingUsername = "username"
+	bindingPassword = "password"
+	bindingDatabase = "database"
+	bindingURI      = "uri"
+)
+
+// bindingEntryAliases are the provider-specific keys copied to the well-known entries, when they are not set
+var bindingEntryAliases = map[string][]string{
+	bindingHost:     {"hostname", "server"},
+	bindingUsername: {"user"},
+	bindingDatabase: {"dbname", "db"},
+	bindingURI:      {"url", "connectionString"},
+}
+
+// bindingSecretName returns the name of the binding secret generated for a connection
+func bindingSecretName(connection *v1beta1.DBaaSConnection) string {
+	return connection.Name + "-binding"
+}
+
+// reconcileBindingSecret generates the servicebinding.io binding secret from the connection's credentials secret
+// and connection information ConfigMap, and sets the secret in the connection's status
+func (r *DBaaSConnectionReconciler) reconcileBindingSecret(ctx context.Context, connection *v1beta1.DBaaSConnection,
+	inventory *v1beta1.DBaaSInventory, provider *v1beta1.DBaaSProvider) error {
+	if connection.Status.CredentialsRef == nil || len(connection.Status.CredentialsRef.Name) == 0 {
+		return nil
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	credentials := &v1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: connection.Status.CredentialsRef.Name, Namespace: connection.Namespace}, credentials); err != nil {
+		if errors.IsNotFound(err) {
+			// the binding secret is generated once the provider creates the secret
+			logger.V(1).Info("Credentials secret not found for the binding secret", "secret", connection.Status.CredentialsRef.Name)
+			return nil
+		}
+		return err
+	}
+	var connectionInfo *v1.ConfigMap
+	if connection.Status.ConnectionInfoRef != nil && len(connection.Status.ConnectionInfoRef.Name) > 0 {
+		connectionInfo = &v1.ConfigMap{}
+		if err := r.Get(ctx, types.NamespacedName{Name: connection.Status.ConnectionInfoRef.Name, Namespace: connection.Namespace}, connectionInfo); err != nil {
+			if errors.IsNotFound(err) {
+				logger.V(1).Info("Connection information ConfigMap not found for the binding secret", "configMap", connection.Status.ConnectionInfoRef.Name)
+				return nil
+			}
+			return err
+		}
+	}
+
+	defaults, err := r.bindingDefaults(ctx, connection, inventory, provider)
+	if err != nil {
+		return err
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bindingSecretName(connection),
+			Namespace: connection.Namespace,
+		},
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if len(secret.ResourceVersion) > 0 {
+			if owns, err := isOwner(connection, secret, r.Scheme); err != nil {
+				return err
+			} else if !owns {
+				return fmt.Errorf("secret %s already exists and is not owned by the DBaaS Connection", secret.Name)
+			}
+		}
+		if secret.Labels == nil {
+			secret.Labels = make(map[string]string, 1)
+		}
+		secret.Labels["managed-by"] = "dbaas-operator"
+		if len(secret.Type) == 0 {
+			secret.Type = v1.SecretTypeOpaque
+		}
+		secret.Data = buildBindingData(credentials, connectionInfo, defaults, connection.Spec.ConnectionStringFormats)
+		return ctrl.SetControllerReference(connection, secret, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	logger.V(1).Info("Binding secret reconciled", "secret", secret.Name, "result", result)
+	connection.Status.Binding = &v1.LocalObjectReference{Name: secret.Name}
+	return nil
+}
+
+// bindingDefaults returns the values of the required type and provider entries, used when the provider does not set them.
+// The type is the database type of the connection's instance, or of the database service in the inventory.
+// The provider is the display name of the provider, or the name of the provider of the inventory.
+func (r *DBaaSConnectionReconciler) bindingDefaults(ctx context.Context, connection *v1beta1.DBaaSConnection,
+	inventory *v1beta1.DBaaSInventory, provider *v1beta1.DBaaSProvider) (map[string]string, error) {
+	defaults := map[string]string{}
+	if provider != nil && len(provider.Spec.Provider.DisplayName) > 0 {
+		defaults[bindingProvider] = provider.Spec.Provider.DisplayName
+	} else if len(inventory.Spec.ProviderRef.Name) > 0 {
+		defaults[bindingProvider] = inventory.Spec.ProviderRef.Name
+	}
+
+	serviceID := connection.Spec.DatabaseServiceID
+	if ref := connection.Spec.DatabaseServiceRef; ref != nil && len(ref.Name) > 0 && connection.Spec.DatabaseServiceType == nil {
+		instance := &v1beta1.DBaaSInstance{}
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, instance); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, err
+			}
+		} else {
+			if dbType := instance.Spec.ProvisioningParameters[v1beta1.ProvisioningDatabaseType]; len(dbType) > 0 {
+				defaults[bindingType] = dbType
+				return defaults, nil
+			}
+			serviceID = instance.Status.InstanceID
+		}
+	}
+	for _, service := range inventory.Status.DatabaseServices {
+		if service.ServiceID == serviceID && len(serviceID) > 0 {
+			if dbType := service.ServiceInfo[string(v1beta1.ProvisioningDatabaseType)]; len(dbType) > 0 {
+				defaults[bindingType] = dbType
+			}
+			break
+		}
+	}
+	return defaults, nil
+}
+
+// buildBindingData merges the connection information and the credentials, and sets the well-known servicebinding.io entries
+// and the connection strings. The defaults are set when neither the connection information nor the credentials contain them.
+func buildBindingData(credentials *v1.Secret, connectionInfo *v1.ConfigMap, defaults map[string]string,
+	formats []v1beta1.ConnectionStringFormat) map[string][]byte {
+	data := map[string][]byte{}
+	if connectionInfo != nil {
+		for key, value := range connectionInfo.BinaryData {
+			data[key] = value
+		}
+		for key, value := range connectionInfo.Data {
+			data[key] = []byte(value)
+		}
+	}
+	for key, value := range credentials.Data {
+		data[key] = value
+	}
+	for key, value := range credentials.StringData {
+		data[key] = []byte(value)
+	}
+
+	for key, value := range defaults {
+		if len(data[key]) == 0 && len(value) > 0 {
+			data[key] = []byte(value)
+		}
+	}
+	for entry, aliases := range bindingEntryAliases {
+		if len(data[entry]) > 0 {
+			continue
+		}
+		for _, alias := range aliases {
+			if len(data[alias]) > 0 {
+				data[entry] = data[alias]
+				break
+			}
+		}
+	}
+	if len(data[bindingURI]) == 0 {
+		if uri := bindingConnectionURI(data); len(uri) > 0 {
+			data[bindingURI] = []byte(uri)
+		}
+	}
+	addConnectionStrings(data, formats)
+	return data
+}
+
+// bindingConnectionURI builds the connection URI from the well-known entries, or returns an empty string if the entries are not enough
+func bindingConnectionURI(data map[string][]byte) string {
+	dbType := strings.ToLower(string(data[bindingType]))
+	host := string(data[bindingHost])
+	if len(dbType) == 0 || len(host) == 0 {
+		return ""
+	}
+
+	scheme := dbType
+	switch dbType {
+	case "postgres", "postgresql":
+		scheme = "postgresql"
+	case "mongodb":
+		if string(data["srv"]) == "true" {
+			scheme = "mongodb+srv"
+		}
+	}
+	uri := url.URL{Scheme: scheme, Host: host}
+	if port := string(data[bindingPort]); len(port) > 0 && scheme != "mongodb+srv" {
+		uri.Host = net.JoinHostPort(host, port)
+	}
+	if username := string(data[bindingUsername]); len(username) > 0 {
+		if password, ok := data[bindingPassword]; ok {
+			uri.User = url.UserPassword(username, string(password))
+		} else {
+			uri.User = url.User(username)
+		}
+	}
+	if database := string(data[bindingDatabase]); len(database) > 0 {
+		uri.Path = "/" + database
+	}
+	return uri.String()
+}
+
+// bindingSecretRequests enqueues the connections using a secret as credentials or binding secret
+func (r *DBaaSConnectionReconciler) bindingSecretRequests(o client.Object) []reconcile.Request {
+	return r.bindingSourceRequests(o, func(connection *v1beta1.DBaaSConnection) []*v1.LocalObjectReference {
+		return []*v1.LocalObjectReference{connection.Status.CredentialsRef, connection.Status.Binding}
+	})
+}
+
+// bindingConfigMapRequests enqueues the connections using a ConfigMap as connection information
+func (r *DBaaSConnectionReconciler) bindingConfigMapRequests(o client.Object) []reconcile.Request {
+	return r.bindingSourceRequests(o, func(connection *v1beta1.DBaaSConnection) []*v1.LocalObjectReference {
+		return []*v1.LocalObjectReference{connection.Status.ConnectionInfoRef}
+	})
+}
+
+// bindingSourceRequests enqueues the connections referencing an object, so their binding secret stays in sync
+func (r *DBaaSConnectionReconciler) bindingSourceRequests(o client.Object, refsFn func(*v1beta1.DBaaSConnection) []*v1.LocalObjectReference) []reconcile.Request {
+	var connectionList v1beta1.DBaaSConnectionList
+	if err := r.List(context.Background(), &connectionList, client.InNamespace(o.GetNamespace())); err != nil {
+		ctrl.Log.Error(err, "Error listing DBaaS Connections for the binding source change", "object", o.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range connectionList.Items {
+		connection := &connectionList.Items[i]
+		for _, ref := range refsFn(connection) {
+			if ref != nil && ref.Name == o.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: connection.Name, Namespace: connection.Namespace}})
+				break
+			}
+		}
+	}
+	return requests
+}
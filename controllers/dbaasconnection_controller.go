@@ -19,25 +19,47 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	appv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/metrics"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/providerapi"
 )
 
+const (
+	defaultRotationGracePeriod = time.Hour
+	// rotationTimeout is how long the DBaaS Operator waits for the provider to issue new credentials
+	rotationTimeout = 30 * time.Minute
+)
+
+// serviceBindingListKinds are the service binding APIs used to find the workloads bound to a connection
+var serviceBindingListKinds = []schema.GroupVersionKind{
+	{Group: "servicebinding.io", Version: "v1beta1", Kind: "ServiceBindingList"},
+	{Group: "binding.operators.coreos.com", Version: "v1alpha1", Kind: "ServiceBindingList"},
+}
+
 // DBaaSConnectionReconciler reconciles a DBaaSConnection object
 type DBaaSConnectionReconciler struct {
 	*DBaaSReconciler
@@ -46,7 +68,11 @@ type DBaaSConnectionReconciler struct {
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
-//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=statefulsets;daemonsets,verbs=get;list;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=servicebinding.io;binding.operators.coreos.com,resources=servicebindings,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -78,18 +104,19 @@ func (r *DBaaSConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		event = metrics.LabelEventValueCreate
 	}
 
-	res, err := r.reconcileDevTopologyResource(ctx, &connection)
+	devTopology := r.getDevTopologyMode(ctx)
+	res, err := r.reconcileDevTopologyResource(ctx, &connection, devTopology)
 	if err != nil {
 		if errors.IsConflict(err) {
-			logger.V(1).Info("Deployment for Developer Topology view modified, retry reconciling")
+			logger.V(1).Info("Developer Topology view resources modified, retry reconciling")
 			metricLabelErrCdValue = metrics.LabelErrorCdValueDevTopologyModified
 			return ctrl.Result{Requeue: true}, nil
 		}
-		logger.Error(err, "Error reconciling Deployment for Developer Topology view")
+		logger.Error(err, "Error reconciling Developer Topology view resources")
 		metricLabelErrCdValue = metrics.LabelErrorCdValueErrReconcilingWithDevTopology
 		return ctrl.Result{}, err
 	}
-	logger.Info("Deployment for Developer Topology view reconciled", "result", res)
+	logger.Info("Developer Topology view reconciled", "mode", devTopology, "result", res)
 
 	if inventory, validNS, _, err := r.checkInventory(ctx, connection.Spec.InventoryRef, &connection, func(reason string, message string) {
 		cond := metav1.Condition{
@@ -123,6 +150,28 @@ func (r *DBaaSConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+		converter, err := providerapi.ForProvider(provider)
+		if err != nil {
+			logger.Error(err, "Error converting the DBaaS Connection for the provider")
+			return ctrl.Result{}, err
+		}
+		providerSpec, err := converter.ConnectionSpec(spec)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if requested, err := r.requestScheduledRotation(ctx, &connection); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Connection modified, retry requesting credentials rotation")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error requesting credentials rotation")
+			return ctrl.Result{}, err
+		} else if requested {
+			logger.Info("Credentials rotation requested", "interval", connection.Spec.Rotation.Interval.Duration)
+			return ctrl.Result{}, nil
+		}
+		lastRotationTime := connection.Status.LastRotationTime
+		observedRotationRequest := connection.Status.ObservedRotationRequest
 		result, err := r.reconcileProviderResource(ctx,
 			inventory.Spec.ProviderRef.Name,
 			&connection,
@@ -130,28 +179,11 @@ func (r *DBaaSConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 				return provider.Spec.ConnectionKind
 			},
 			func() interface{} {
-				if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
-					providerSpec := &v1alpha1.DBaaSConnectionSpec{}
-					providerSpec.ConvertFrom(spec)
-					return providerSpec
-				}
-				return spec
-			},
-			func() interface{} {
-				if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
-					return &v1alpha1.DBaaSProviderConnection{}
-				}
-				return &v1beta1.DBaaSProviderConnection{}
+				return providerSpec
 			},
+			converter.NewConnection,
 			func(i interface{}) metav1.Condition {
-				if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
-					providerConnV1alpha1 := i.(*v1alpha1.DBaaSProviderConnection)
-					providerConnV1beta1 := &v1beta1.DBaaSProviderConnection{}
-					providerConnV1alpha1.Status.ConvertTo(&providerConnV1beta1.Status)
-					return mergeConnectionStatus(&connection, providerConnV1beta1)
-				}
-				providerConn := i.(*v1beta1.DBaaSProviderConnection)
-				return mergeConnectionStatus(&connection, providerConn)
+				return r.syncConnectionStatus(ctx, &connection, converter.ConvertConnection(i), inventory, provider)
 			},
 			func() *[]metav1.Condition {
 				return &connection.Status.Conditions
@@ -159,6 +191,32 @@ func (r *DBaaSConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			v1beta1.DBaaSConnectionReadyType,
 			logger,
 		)
+		if err == nil && !result.Requeue {
+			if !connection.Status.LastRotationTime.Equal(lastRotationTime) {
+				logger.Info("Credentials rotated", "credentialsRef", connection.Status.CredentialsRef)
+			}
+			if connection.Status.ObservedRotationRequest != observedRotationRequest {
+				if err := r.clearRotationRequest(ctx, &connection, provider); err != nil {
+					logger.Error(err, "Error removing the credentials rotation request from the provider connection")
+					return ctrl.Result{}, err
+				}
+			}
+			if connection.Status.WorkloadsRestartPending {
+				// the restart is retried until it succeeds
+				if err := r.restartBoundWorkloads(ctx, &connection); err != nil {
+					logger.Error(err, "Error restarting the workloads bound to the DBaaS Connection")
+					return ctrl.Result{}, err
+				}
+				connection.Status.WorkloadsRestartPending = false
+				if err := r.updateDBaaSObjectStatus(ctx, &connection); err != nil {
+					logger.Error(err, "Error updating the DBaaS Connection status")
+					return ctrl.Result{}, err
+				}
+			}
+			if requeueAfter := nextRotationCheck(&connection, time.Now()); requeueAfter > 0 {
+				result.RequeueAfter = requeueAfter
+			}
+		}
 		defer func() {
 			metrics.SetConnectionMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, connection, execution, event, metricLabelErrCdValue)
 		}()
@@ -168,24 +226,118 @@ func (r *DBaaSConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSConnectionReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
-	return ctrl.NewControllerManagedBy(mgr).
+	return r.policyWatches(ctrl.NewControllerManagedBy(mgr), func() client.ObjectList {
+		return &v1beta1.DBaaSConnectionList{}
+	}).
 		For(&v1beta1.DBaaSConnection{}).
 		Watches(&source.Kind{Type: &v1beta1.DBaaSConnection{}}, &EventHandlerWithDelete{Controller: r}).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSPlatform{}}, handler.EnqueueRequestsFromMapFunc(r.platformConnectionRequests),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &v1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.bindingSecretRequests), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &v1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.bindingConfigMapRequests), builder.OnlyMetadata).
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 2},
 		).
 		Build(r)
 }
 
-func (r *DBaaSConnectionReconciler) reconcileDevTopologyResource(ctx context.Context, connection *v1beta1.DBaaSConnection) (controllerutil.OperationResult, error) {
-	deployment := &appv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      connection.Name,
-			Namespace: connection.Namespace,
-		},
+// platformConnectionRequests enqueues all the connections when the DBaaS Platform settings change
+func (r *DBaaSConnectionReconciler) platformConnectionRequests(o client.Object) []reconcile.Request {
+	if o.GetNamespace() != r.InstallNamespace {
+		return nil
+	}
+	var connectionList v1beta1.DBaaSConnectionList
+	if err := r.List(context.Background(), &connectionList); err != nil {
+		ctrl.Log.Error(err, "Error listing DBaaS Connections for the DBaaS Platform settings change")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(connectionList.Items))
+	for _, connection := range connectionList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: connection.Name, Namespace: connection.Namespace}})
+	}
+	return requests
+}
+
+// getDevTopologyMode returns the Developer Topology view mode set in the DBaaS Platform, or the default.
+// The platform is read from the cache, its changes requeue all the connections.
+func (r *DBaaSConnectionReconciler) getDevTopologyMode(ctx context.Context) v1beta1.DevTopologyMode {
+	var platform v1beta1.DBaaSPlatform
+	if err := r.Get(ctx, types.NamespacedName{Name: platformCRName, Namespace: r.InstallNamespace}, &platform); err != nil {
+		if !errors.IsNotFound(err) {
+			ctrl.LoggerFrom(ctx).Error(err, "Error reading the DBaaS Platform, using the default Developer Topology view mode")
+		}
+		return v1beta1.DevTopologyConnection
+	}
+	if len(platform.Spec.DevTopology) > 0 {
+		return platform.Spec.DevTopology
+	}
+	return v1beta1.DevTopologyConnection
+}
+
+func (r *DBaaSConnectionReconciler) reconcileDevTopologyResource(ctx context.Context, connection *v1beta1.DBaaSConnection, mode v1beta1.DevTopologyMode) (controllerutil.OperationResult, error) {
+	if mode == v1beta1.DevTopologyDeployment {
+		deployment := &appv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      connection.Name,
+				Namespace: connection.Namespace,
+			},
+		}
+		result, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, r.deploymentMutateFn(connection, deployment))
+		if err != nil {
+			return result, err
+		}
+		if _, err := r.setTopologyNodeLabel(ctx, connection, false); err != nil {
+			return result, err
+		}
+		return result, nil
+	}
+
+	if err := r.deleteDevTopologyDeployment(ctx, connection); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	return r.setTopologyNodeLabel(ctx, connection, mode != v1beta1.DevTopologyDisabled)
+}
+
+// deleteDevTopologyDeployment removes the legacy bind-deploy Deployment created for the connection
+func (r *DBaaSConnectionReconciler) deleteDevTopologyDeployment(ctx context.Context, connection *v1beta1.DBaaSConnection) error {
+	deployment := &appv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Name: connection.Name, Namespace: connection.Namespace}, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if deployment.Spec.Selector == nil || deployment.Spec.Selector.MatchLabels["name"] != "bind-deploy" {
+		return nil
+	}
+	if owns, err := isOwner(connection, deployment, r.Scheme); err != nil || !owns {
+		return err
+	}
+	if err := r.Client.Delete(ctx, deployment); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).Info("Deleted the Deployment for Developer Topology view", "deployment", deployment.Name)
+	return nil
+}
+
+// setTopologyNodeLabel adds or removes the label used by the console plugin to show the connection in the Topology view
+func (r *DBaaSConnectionReconciler) setTopologyNodeLabel(ctx context.Context, connection *v1beta1.DBaaSConnection, enabled bool) (controllerutil.OperationResult, error) {
+	_, labeled := connection.Labels[v1beta1.TopologyNodeLabelKey]
+	if labeled == enabled {
+		return controllerutil.OperationResultNone, nil
+	}
+	if enabled {
+		if connection.Labels == nil {
+			connection.Labels = map[string]string{}
+		}
+		connection.Labels[v1beta1.TopologyNodeLabelKey] = "true"
+	} else {
+		delete(connection.Labels, v1beta1.TopologyNodeLabelKey)
+	}
+	if err := r.Update(ctx, connection); err != nil {
+		return controllerutil.OperationResultNone, err
 	}
-	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, r.deploymentMutateFn(connection, deployment))
-	return result, err
+	return controllerutil.OperationResultUpdated, nil
 }
 
 func (r *DBaaSConnectionReconciler) deploymentMutateFn(connection *v1beta1.DBaaSConnection, deployment *appv1.Deployment) controllerutil.MutateFn {
@@ -228,9 +380,28 @@ func (r *DBaaSConnectionReconciler) deploymentMutateFn(connection *v1beta1.DBaaS
 	}
 }
 
+// syncConnectionStatus merges the provider connection status, and reconciles the binding secret
+func (r *DBaaSConnectionReconciler) syncConnectionStatus(ctx context.Context, conn *v1beta1.DBaaSConnection, providerConn *v1beta1.DBaaSProviderConnection,
+	inventory *v1beta1.DBaaSInventory, provider *v1beta1.DBaaSProvider) metav1.Condition {
+	cond := mergeConnectionStatus(conn, providerConn)
+	if err := r.reconcileBindingSecret(ctx, conn, inventory, provider); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Error reconciling the binding secret for the DBaaS Connection")
+		return metav1.Condition{
+			Type:    v1beta1.DBaaSConnectionReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1beta1.DBaaSBindingError,
+			Message: err.Error(),
+		}
+	}
+	return cond
+}
+
 // mergeConnectionStatus: merge the status from DBaaSProviderConnection into the current DBaaSConnection status
 func mergeConnectionStatus(conn *v1beta1.DBaaSConnection, providerConn *v1beta1.DBaaSProviderConnection) metav1.Condition {
+	previousStatus := conn.Status.DeepCopy()
 	providerConn.Status.DeepCopyInto(&conn.Status)
+	conn.Status.Binding = previousStatus.Binding
+	mergeRotationStatus(conn, previousStatus, time.Now())
 	// Update connection status condition (type: DBaaSConnectionReadyType) based on the provider status
 	specSync := apimeta.FindStatusCondition(providerConn.Status.Conditions, v1beta1.DBaaSConnectionProviderSyncType)
 	if specSync != nil && specSync.Status == metav1.ConditionTrue {
@@ -249,6 +420,301 @@ func mergeConnectionStatus(conn *v1beta1.DBaaSConnection, providerConn *v1beta1.
 	}
 }
 
+// mergeRotationStatus keeps the rotation fields owned by the DBaaS Operator, and records a rotation
+// when the provider reports a new credentials secret. A rotation request the provider does not fulfill
+// within the rotation timeout is reported as timed out.
+func mergeRotationStatus(conn *v1beta1.DBaaSConnection, previousStatus *v1beta1.DBaaSConnectionStatus, now time.Time) {
+	conn.Status.PreviousCredentialsRef = previousStatus.PreviousCredentialsRef
+	conn.Status.LastRotationTime = previousStatus.LastRotationTime
+	conn.Status.ObservedRotationRequest = previousStatus.ObservedRotationRequest
+	conn.Status.WorkloadsRestartPending = previousStatus.WorkloadsRestartPending
+	rotationCond := apimeta.FindStatusCondition(previousStatus.Conditions, v1beta1.DBaaSCredentialsRotatedType)
+	if rotationCond != nil {
+		apimeta.SetStatusCondition(&conn.Status.Conditions, *rotationCond)
+	}
+
+	if previousStatus.CredentialsRef != nil && conn.Status.CredentialsRef != nil &&
+		previousStatus.CredentialsRef.Name != conn.Status.CredentialsRef.Name {
+		conn.Status.PreviousCredentialsRef = previousStatus.CredentialsRef.DeepCopy()
+		conn.Status.LastRotationTime = &metav1.Time{Time: now}
+		conn.Status.ObservedRotationRequest = conn.Annotations[v1beta1.RotateCredentialsAnnotation]
+		conn.Status.WorkloadsRestartPending = true
+		setRotationCondition(conn, metav1.ConditionTrue, v1beta1.Ready, v1beta1.MsgCredentialsRotated, now)
+		return
+	}
+
+	if rotationPending(conn) {
+		if rotationCond == nil || rotationCond.Reason != v1beta1.DBaaSRotationPending {
+			setRotationCondition(conn, metav1.ConditionFalse, v1beta1.DBaaSRotationPending, v1beta1.MsgRotationPending, now)
+		} else if !now.Before(rotationCond.LastTransitionTime.Add(rotationTimeout)) {
+			// the request is not relayed to the provider anymore
+			conn.Status.ObservedRotationRequest = conn.Annotations[v1beta1.RotateCredentialsAnnotation]
+			setRotationCondition(conn, metav1.ConditionFalse, v1beta1.DBaaSRotationTimedOut,
+				fmt.Sprintf("The provider did not issue new credentials within %s", rotationTimeout), now)
+		}
+	}
+
+	if conn.Status.PreviousCredentialsRef != nil && conn.Status.LastRotationTime != nil &&
+		!now.Before(conn.Status.LastRotationTime.Add(rotationGracePeriod(conn))) {
+		conn.Status.PreviousCredentialsRef = nil
+	}
+}
+
+// setRotationCondition sets the credentials rotation condition, its transition time is the time of the last change of reason
+func setRotationCondition(conn *v1beta1.DBaaSConnection, status metav1.ConditionStatus, reason, message string, now time.Time) {
+	apimeta.RemoveStatusCondition(&conn.Status.Conditions, v1beta1.DBaaSCredentialsRotatedType)
+	apimeta.SetStatusCondition(&conn.Status.Conditions, metav1.Condition{
+		Type:               v1beta1.DBaaSCredentialsRotatedType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Time{Time: now},
+	})
+}
+
+// rotationPending returns true if a credentials rotation was requested, but the provider has not issued new credentials yet
+func rotationPending(conn *v1beta1.DBaaSConnection) bool {
+	request := conn.Annotations[v1beta1.RotateCredentialsAnnotation]
+	return len(request) > 0 && request != conn.Status.ObservedRotationRequest
+}
+
+// setRotationRequest relays a pending rotation request of the connection to the provider connection,
+// and removes the request once the provider has issued the new credentials
+func setRotationRequest(conn *v1beta1.DBaaSConnection, providerObject *unstructured.Unstructured) {
+	annotations := providerObject.GetAnnotations()
+	if rotationPending(conn) {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[v1beta1.RotateCredentialsAnnotation] = conn.Annotations[v1beta1.RotateCredentialsAnnotation]
+	} else if _, ok := annotations[v1beta1.RotateCredentialsAnnotation]; ok {
+		delete(annotations, v1beta1.RotateCredentialsAnnotation)
+	} else {
+		return
+	}
+	providerObject.SetAnnotations(annotations)
+}
+
+// clearRotationRequest removes the rotation request from the provider connection, once the rotation is recorded
+func (r *DBaaSConnectionReconciler) clearRotationRequest(ctx context.Context, conn *v1beta1.DBaaSConnection, provider *v1beta1.DBaaSProvider) error {
+	providerObject := r.createProviderObject(conn, provider.GetDBaaSAPIGroupVersion(), provider.Spec.ConnectionKind)
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, v1beta1.RotateCredentialsAnnotation)
+	return client.IgnoreNotFound(r.Patch(ctx, providerObject, client.RawPatch(types.MergePatchType, []byte(patch))))
+}
+
+// rotationGracePeriod returns how long the previous credentials stay valid after a rotation
+func rotationGracePeriod(conn *v1beta1.DBaaSConnection) time.Duration {
+	if conn.Spec.Rotation != nil && conn.Spec.Rotation.GracePeriod != nil {
+		return conn.Spec.Rotation.GracePeriod.Duration
+	}
+	return defaultRotationGracePeriod
+}
+
+// nextRotationDue returns the time of the next scheduled rotation, or the zero time if there is no rotation interval
+func nextRotationDue(conn *v1beta1.DBaaSConnection) time.Time {
+	if conn.Spec.Rotation == nil || conn.Spec.Rotation.Interval == nil {
+		return time.Time{}
+	}
+	last := conn.CreationTimestamp
+	if conn.Status.LastRotationTime != nil {
+		last = *conn.Status.LastRotationTime
+	}
+	// a timed out rotation is requested again after the interval
+	if cond := apimeta.FindStatusCondition(conn.Status.Conditions, v1beta1.DBaaSCredentialsRotatedType); cond != nil &&
+		cond.Reason == v1beta1.DBaaSRotationTimedOut && last.Before(&cond.LastTransitionTime) {
+		last = cond.LastTransitionTime
+	}
+	return last.Add(conn.Spec.Rotation.Interval.Duration)
+}
+
+// nextRotationCheck returns when the connection needs to be reconciled again to request a scheduled rotation,
+// to time out a rotation request, or to end the grace period of the previous credentials, or zero if no check is needed
+func nextRotationCheck(conn *v1beta1.DBaaSConnection, now time.Time) time.Duration {
+	var next time.Duration
+	updateNext := func(at time.Time) {
+		if d := at.Sub(now); d > 0 && (next == 0 || d < next) {
+			next = d
+		}
+	}
+	if due := nextRotationDue(conn); !due.IsZero() && !rotationPending(conn) {
+		updateNext(due)
+	}
+	if cond := apimeta.FindStatusCondition(conn.Status.Conditions, v1beta1.DBaaSCredentialsRotatedType); cond != nil &&
+		cond.Reason == v1beta1.DBaaSRotationPending && rotationPending(conn) {
+		updateNext(cond.LastTransitionTime.Add(rotationTimeout))
+	}
+	if conn.Status.PreviousCredentialsRef != nil && conn.Status.LastRotationTime != nil {
+		updateNext(conn.Status.LastRotationTime.Add(rotationGracePeriod(conn)))
+	}
+	return next
+}
+
+// requestScheduledRotation sets the rotation annotation on the connection when the rotation interval has elapsed
+func (r *DBaaSConnectionReconciler) requestScheduledRotation(ctx context.Context, conn *v1beta1.DBaaSConnection) (bool, error) {
+	due := nextRotationDue(conn)
+	if due.IsZero() || rotationPending(conn) || time.Now().Before(due) {
+		return false, nil
+	}
+	if conn.Annotations == nil {
+		conn.Annotations = map[string]string{}
+	}
+	conn.Annotations[v1beta1.RotateCredentialsAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := r.Update(ctx, conn); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// restartBoundWorkloads restarts the workloads bound to the connection through service binding,
+// so they pick up the new credentials
+func (r *DBaaSConnectionReconciler) restartBoundWorkloads(ctx context.Context, conn *v1beta1.DBaaSConnection) error {
+	logger := ctrl.LoggerFrom(ctx)
+	rotatedAt := conn.Status.LastRotationTime.UTC().Format(time.RFC3339)
+
+	workloads, err := r.getBoundWorkloads(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	restarted := map[string]bool{}
+	for _, workload := range workloads {
+		key := workload.GroupVersionKind().Kind + "/" + workload.GetName()
+		if restarted[key] {
+			continue
+		}
+		restarted[key] = true
+		patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, v1beta1.CredentialsRotatedAtAnnotation, rotatedAt))
+		if err := r.Patch(ctx, workload, client.RawPatch(types.MergePatchType, patch)); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			errs = append(errs, err)
+			continue
+		}
+		logger.Info("Workload restarted after credentials rotation", "workload", key)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// getBoundWorkloads returns the workloads of the service bindings that reference the connection
+func (r *DBaaSConnectionReconciler) getBoundWorkloads(ctx context.Context, conn *v1beta1.DBaaSConnection) ([]*unstructured.Unstructured, error) {
+	var workloads []*unstructured.Unstructured
+	for _, gvk := range serviceBindingListKinds {
+		bindings := &unstructured.UnstructuredList{}
+		bindings.SetGroupVersionKind(gvk)
+		if err := r.List(ctx, bindings, client.InNamespace(conn.Namespace)); err != nil {
+			if apimeta.IsNoMatchError(err) || errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		for i := range bindings.Items {
+			workloadGVK, name, selector, found, err := r.parseServiceBinding(&bindings.Items[i], conn)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				continue
+			}
+			if len(name) > 0 {
+				workload := &unstructured.Unstructured{}
+				workload.SetGroupVersionKind(workloadGVK)
+				workload.SetName(name)
+				workload.SetNamespace(conn.Namespace)
+				workloads = append(workloads, workload)
+				continue
+			}
+			if selector == nil {
+				continue
+			}
+			labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+			if err != nil {
+				return nil, err
+			}
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(workloadGVK.GroupVersion().WithKind(workloadGVK.Kind + "List"))
+			if err := r.List(ctx, list, client.InNamespace(conn.Namespace), client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+				return nil, err
+			}
+			for j := range list.Items {
+				workloads = append(workloads, &list.Items[j])
+			}
+		}
+	}
+	return workloads, nil
+}
+
+// parseServiceBinding returns the workload of a service binding, if one of its services is the connection
+func (r *DBaaSConnectionReconciler) parseServiceBinding(binding *unstructured.Unstructured, conn *v1beta1.DBaaSConnection) (schema.GroupVersionKind, string, *metav1.LabelSelector, bool, error) {
+	isConnection := func(apiVersion, kind, name, namespace string) bool {
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		return err == nil && gv.Group == v1beta1.GroupVersion.Group && kind == "DBaaSConnection" && name == conn.Name &&
+			(len(namespace) == 0 || namespace == conn.Namespace)
+	}
+
+	var workloadSpec map[string]interface{}
+	if binding.GroupVersionKind().Group == "servicebinding.io" {
+		service, _, _ := unstructured.NestedStringMap(binding.Object, "spec", "service")
+		if !isConnection(service["apiVersion"], service["kind"], service["name"], "") {
+			return schema.GroupVersionKind{}, "", nil, false, nil
+		}
+		workloadSpec, _, _ = unstructured.NestedMap(binding.Object, "spec", "workload")
+	} else {
+		services, _, _ := unstructured.NestedSlice(binding.Object, "spec", "services")
+		found := false
+		for _, s := range services {
+			if service, ok := s.(map[string]interface{}); ok {
+				group, _, _ := unstructured.NestedString(service, "group")
+				version, _, _ := unstructured.NestedString(service, "version")
+				kind, _, _ := unstructured.NestedString(service, "kind")
+				name, _, _ := unstructured.NestedString(service, "name")
+				namespace, _, _ := unstructured.NestedString(service, "namespace")
+				if isConnection(schema.GroupVersion{Group: group, Version: version}.String(), kind, name, namespace) {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return schema.GroupVersionKind{}, "", nil, false, nil
+		}
+		workloadSpec, _, _ = unstructured.NestedMap(binding.Object, "spec", "application")
+	}
+	if workloadSpec == nil {
+		return schema.GroupVersionKind{}, "", nil, false, nil
+	}
+
+	var gvk schema.GroupVersionKind
+	if apiVersion, ok, _ := unstructured.NestedString(workloadSpec, "apiVersion"); ok {
+		gvk = schema.FromAPIVersionAndKind(apiVersion, "")
+	} else {
+		gvk.Group, _, _ = unstructured.NestedString(workloadSpec, "group")
+		gvk.Version, _, _ = unstructured.NestedString(workloadSpec, "version")
+	}
+	gvk.Kind, _, _ = unstructured.NestedString(workloadSpec, "kind")
+	if len(gvk.Kind) == 0 {
+		resource, _, _ := unstructured.NestedString(workloadSpec, "resource")
+		kind, err := r.RESTMapper().KindFor(gvk.GroupVersion().WithResource(resource))
+		if err != nil {
+			return schema.GroupVersionKind{}, "", nil, false, err
+		}
+		gvk = kind
+	}
+
+	name, _, _ := unstructured.NestedString(workloadSpec, "name")
+	var selector *metav1.LabelSelector
+	for _, selectorField := range []string{"selector", "labelSelector"} {
+		if s, ok, _ := unstructured.NestedMap(workloadSpec, selectorField); ok {
+			selector = &metav1.LabelSelector{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(s, selector); err != nil {
+				return schema.GroupVersionKind{}, "", nil, false, err
+			}
+		}
+	}
+	return gvk, name, selector, true, nil
+}
+
 // Delete implements a handler for the Delete event.
 func (r *DBaaSConnectionReconciler) Delete(e event.DeleteEvent) error {
 	execution := metrics.PlatformInstallStart()
@@ -279,35 +745,16 @@ func (r *DBaaSConnectionReconciler) getConnectionSpec(ctx context.Context, spec
 		return spec, nil
 	}
 
-	databaseServiceRef := spec.DatabaseServiceRef
-
-	if databaseServiceRef == nil || len(databaseServiceRef.Name) == 0 {
-		return nil, fmt.Errorf("database service reference is not properly set")
+	if spec.DatabaseServiceRef != nil && len(spec.DatabaseServiceRef.Name) > 0 && spec.DatabaseServiceType != nil {
+		return nil, fmt.Errorf("using database service reference of type %v is not supported", spec.DatabaseServiceType)
 	}
 
-	if spec.DatabaseServiceType == nil {
-		instance := &v1beta1.DBaaSInstance{}
-		if err := r.Get(ctx, types.NamespacedName{
-			Name:      databaseServiceRef.Name,
-			Namespace: databaseServiceRef.Namespace,
-		}, instance); err != nil {
-			return nil, fmt.Errorf("cannot read the instance reference")
-		}
-
-		if instance.Spec.InventoryRef.Namespace != spec.InventoryRef.Namespace ||
-			instance.Spec.InventoryRef.Name != spec.InventoryRef.Name {
-			return nil, fmt.Errorf("instance and connection don't use the same inventory reference")
-		}
-
-		if len(instance.Status.InstanceID) == 0 {
-			return nil, fmt.Errorf("instance ID is not available")
-		}
-
-		spec.DatabaseServiceID = instance.Status.InstanceID
-		spec.DatabaseServiceRef = nil
-	} else {
-		return nil, fmt.Errorf("using database service reference of type %v is not supported", spec.DatabaseServiceType)
+	instanceID, err := r.getReferencedInstanceID(ctx, spec.InventoryRef, spec.DatabaseServiceRef)
+	if err != nil {
+		return nil, err
 	}
+	spec.DatabaseServiceID = instanceID
+	spec.DatabaseServiceRef = nil
 	return spec, nil
 }
 
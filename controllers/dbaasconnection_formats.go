@@ -0,0 +1,292 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// connectionStringEntries are the binding secret entries of the connection string formats
+var connectionStringEntries = map[v1beta1.ConnectionStringFormat]string{
+	v1beta1.ConnectionStringJDBC:       "jdbc-url",
+	v1beta1.ConnectionStringDSN:        "dsn",
+	v1beta1.ConnectionStringSQLAlchemy: "sqlalchemy-url",
+	v1beta1.ConnectionStringMongoDB:    "mongodb-uri",
+	v1beta1.ConnectionStringDotNet:     "dotnet-connection-string",
+}
+
+// connectionParams holds the well-known binding entries used to render the connection strings
+type connectionParams struct {
+	host     string
+	port     string
+	database string
+	username string
+	password string
+	sslMode  string
+}
+
+// connectionStringRenderer renders a connection string format for a database type
+type connectionStringRenderer func(params connectionParams) string
+
+// connectionStringRenderers are the supported connection string formats for each database type
+var connectionStringRenderers = map[string]map[v1beta1.ConnectionStringFormat]connectionStringRenderer{
+	"postgresql": {
+		v1beta1.ConnectionStringJDBC:       postgresJDBC,
+		v1beta1.ConnectionStringDSN:        postgresDSN,
+		v1beta1.ConnectionStringSQLAlchemy: postgresSQLAlchemy,
+		v1beta1.ConnectionStringDotNet:     postgresDotNet,
+	},
+	"mysql": {
+		v1beta1.ConnectionStringJDBC:       mysqlJDBC,
+		v1beta1.ConnectionStringDSN:        mysqlDSN,
+		v1beta1.ConnectionStringSQLAlchemy: mysqlSQLAlchemy,
+		v1beta1.ConnectionStringDotNet:     mysqlDotNet,
+	},
+	"sqlserver": {
+		v1beta1.ConnectionStringJDBC:       sqlServerJDBC,
+		v1beta1.ConnectionStringDSN:        sqlServerDSN,
+		v1beta1.ConnectionStringSQLAlchemy: sqlServerSQLAlchemy,
+		v1beta1.ConnectionStringDotNet:     sqlServerDotNet,
+	},
+}
+
+// defaultPorts are the ports used in the connection strings when the binding secret has no port entry
+var defaultPorts = map[string]string{
+	"postgresql": "5432",
+	"mysql":      "3306",
+	"sqlserver":  "1433",
+}
+
+// normalizeDatabaseType returns the database type used to select the connection string formats
+func normalizeDatabaseType(dbType string) string {
+	switch dbType = strings.ToLower(dbType); dbType {
+	case "postgres", "postgresql", "cockroachdb":
+		return "postgresql"
+	case "mysql", "mariadb":
+		return "mysql"
+	case "sqlserver", "mssql":
+		return "sqlserver"
+	default:
+		return dbType
+	}
+}
+
+// addConnectionStrings renders the requested connection string formats that apply to the database type into the binding data,
+// all the formats that apply are rendered if none is requested
+func addConnectionStrings(data map[string][]byte, formats []v1beta1.ConnectionStringFormat) {
+	dbType := normalizeDatabaseType(string(data[bindingType]))
+	if len(data[bindingHost]) == 0 {
+		return
+	}
+	if len(formats) == 0 {
+		formats = []v1beta1.ConnectionStringFormat{
+			v1beta1.ConnectionStringJDBC,
+			v1beta1.ConnectionStringDSN,
+			v1beta1.ConnectionStringSQLAlchemy,
+			v1beta1.ConnectionStringMongoDB,
+			v1beta1.ConnectionStringDotNet,
+		}
+	}
+
+	params := connectionParams{
+		host:     string(data[bindingHost]),
+		port:     string(data[bindingPort]),
+		database: string(data[bindingDatabase]),
+		username: string(data[bindingUsername]),
+		password: string(data[bindingPassword]),
+		sslMode:  string(data["sslmode"]),
+	}
+	if len(params.port) == 0 {
+		params.port = defaultPorts[dbType]
+	}
+	for _, format := range formats {
+		if format == v1beta1.ConnectionStringMongoDB {
+			if dbType == "mongodb" {
+				data[connectionStringEntries[format]] = []byte(bindingConnectionURI(data))
+			}
+			continue
+		}
+		if render, ok := connectionStringRenderers[dbType][format]; ok {
+			data[connectionStringEntries[format]] = []byte(render(params))
+		}
+	}
+}
+
+func (p connectionParams) hostPort() string {
+	if len(p.port) == 0 {
+		return p.host
+	}
+	return net.JoinHostPort(p.host, p.port)
+}
+
+// urlWithUser renders a URL with the credentials in the user information
+func (p connectionParams) urlWithUser(scheme string, query url.Values) string {
+	uri := url.URL{Scheme: scheme, Host: p.hostPort()}
+	if len(p.username) > 0 {
+		uri.User = url.UserPassword(p.username, p.password)
+	}
+	if len(p.database) > 0 {
+		uri.Path = "/" + p.database
+	}
+	if len(query) > 0 {
+		uri.RawQuery = query.Encode()
+	}
+	return uri.String()
+}
+
+// jdbcURL renders a JDBC URL with the credentials in the query parameters
+func (p connectionParams) jdbcURL(subprotocol string, query url.Values) string {
+	if len(p.username) > 0 {
+		query.Set("user", p.username)
+		query.Set("password", p.password)
+	}
+	uri := url.URL{Scheme: subprotocol, Host: p.hostPort(), Path: "/" + p.database, RawQuery: query.Encode()}
+	return "jdbc:" + uri.String()
+}
+
+func (p connectionParams) sslModeQuery() url.Values {
+	query := url.Values{}
+	if len(p.sslMode) > 0 {
+		query.Set("sslmode", p.sslMode)
+	}
+	return query
+}
+
+func postgresJDBC(p connectionParams) string {
+	return p.jdbcURL("postgresql", p.sslModeQuery())
+}
+
+func postgresDSN(p connectionParams) string {
+	pairs := []string{"host=" + libpqValue(p.host), "port=" + libpqValue(p.port)}
+	if len(p.database) > 0 {
+		pairs = append(pairs, "dbname="+libpqValue(p.database))
+	}
+	if len(p.username) > 0 {
+		pairs = append(pairs, "user="+libpqValue(p.username), "password="+libpqValue(p.password))
+	}
+	if len(p.sslMode) > 0 {
+		pairs = append(pairs, "sslmode="+libpqValue(p.sslMode))
+	}
+	return strings.Join(pairs, " ")
+}
+
+func postgresSQLAlchemy(p connectionParams) string {
+	return p.urlWithUser("postgresql+psycopg2", p.sslModeQuery())
+}
+
+func postgresDotNet(p connectionParams) string {
+	pairs := [][2]string{{"Host", p.host}, {"Port", p.port}, {"Database", p.database}, {"Username", p.username}, {"Password", p.password}}
+	if len(p.sslMode) > 0 {
+		pairs = append(pairs, [2]string{"SSL Mode", p.sslMode})
+	}
+	return dotNetConnectionString(pairs)
+}
+
+func mysqlJDBC(p connectionParams) string {
+	return p.jdbcURL("mysql", url.Values{})
+}
+
+// mysqlDSN returns the DSN of the Go MySQL driver, formatted by the driver so that it parses back the same credentials
+func mysqlDSN(p connectionParams) string {
+	cfg := mysql.NewConfig()
+	cfg.User = p.username
+	cfg.Passwd = p.password
+	cfg.Net = "tcp"
+	cfg.Addr = p.hostPort()
+	cfg.DBName = p.database
+	return cfg.FormatDSN()
+}
+
+func mysqlSQLAlchemy(p connectionParams) string {
+	return p.urlWithUser("mysql+pymysql", nil)
+}
+
+func mysqlDotNet(p connectionParams) string {
+	return dotNetConnectionString([][2]string{{"Server", p.host}, {"Port", p.port}, {"Database", p.database}, {"Uid", p.username}, {"Pwd", p.password}})
+}
+
+func sqlServerJDBC(p connectionParams) string {
+	jdbc := "jdbc:sqlserver://" + p.hostPort()
+	for _, pair := range [][2]string{{"databaseName", p.database}, {"user", p.username}, {"password", p.password}} {
+		if len(pair[1]) > 0 {
+			jdbc += ";" + pair[0] + "=" + jdbcSQLServerValue(pair[1])
+		}
+	}
+	return jdbc
+}
+
+func sqlServerDSN(p connectionParams) string {
+	query := url.Values{}
+	if len(p.database) > 0 {
+		query.Set("database", p.database)
+	}
+	uri := url.URL{Scheme: "sqlserver", Host: p.hostPort(), RawQuery: query.Encode()}
+	if len(p.username) > 0 {
+		uri.User = url.UserPassword(p.username, p.password)
+	}
+	return uri.String()
+}
+
+func sqlServerSQLAlchemy(p connectionParams) string {
+	return p.urlWithUser("mssql+pymssql", nil)
+}
+
+func sqlServerDotNet(p connectionParams) string {
+	server := p.host
+	if len(p.port) > 0 {
+		server += "," + p.port
+	}
+	return dotNetConnectionString([][2]string{{"Server", server}, {"Database", p.database}, {"User Id", p.username}, {"Password", p.password}})
+}
+
+// libpqValue quotes a value of a libpq keyword/value connection string when needed
+func libpqValue(value string) string {
+	if len(value) > 0 && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// jdbcSQLServerValue escapes a property value of a SQL Server JDBC URL when needed
+func jdbcSQLServerValue(value string) string {
+	if !strings.ContainsAny(value, ";{}=") {
+		return value
+	}
+	return "{" + strings.ReplaceAll(value, "}", "}}") + "}"
+}
+
+// dotNetConnectionString renders the non-empty keyword/value pairs of an ADO.NET connection string
+func dotNetConnectionString(pairs [][2]string) string {
+	var values []string
+	for _, pair := range pairs {
+		if len(pair[1]) == 0 {
+			continue
+		}
+		value := pair[1]
+		if strings.ContainsAny(value, `;"'`) || strings.TrimSpace(value) != value {
+			value = `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+		}
+		values = append(values, pair[0]+"="+value)
+	}
+	return strings.Join(values, ";")
+}
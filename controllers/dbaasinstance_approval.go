@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// requiresApproval returns true if the effective policy of an inventory requires the approval of the instances
+func requiresApproval(effectivePolicy *v1beta1.DBaaSPolicySpec) bool {
+	return effectivePolicy.RequireApproval != nil && *effectivePolicy.RequireApproval
+}
+
+// checkApproval returns true if the instance can be relayed to the provider: it is approved, it does not require
+// an approval, or it was already relayed before approvals were required. The approval is recorded in the status,
+// and an instance waiting for an approval is set in the PendingApproval phase.
+func (r *DBaaSInstanceReconciler) checkApproval(ctx context.Context, instance *v1beta1.DBaaSInstance, inventory *v1beta1.DBaaSInventory,
+	logger logr.Logger) (bool, error) {
+	if instance.Status.Approval != nil || apimeta.FindStatusCondition(instance.Status.Conditions, v1beta1.DBaaSInstanceProviderSyncType) != nil {
+		return true, nil
+	}
+	policyList, err := r.policyListByNS(ctx, inventory.Namespace)
+	if err != nil {
+		return false, err
+	}
+	effectivePolicy, err := r.getEffectivePolicy(ctx, inventory, v1beta1.GetActivePolicy(policyList.Items))
+	if err != nil {
+		return false, err
+	}
+	if !requiresApproval(effectivePolicy) {
+		return true, nil
+	}
+
+	approval, err := r.getInstanceApproval(ctx, instance)
+	if err != nil {
+		return false, err
+	}
+	if approval != nil {
+		logger.Info("DBaaS Instance approved", "approver", approval.Approver)
+		instance.Status.Approval = approval
+		return true, r.updateDBaaSObjectStatus(ctx, instance)
+	}
+
+	instance.Status.Phase = v1beta1.InstancePhasePendingApproval
+	apimeta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSInstanceReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1beta1.DBaaSInstancePendingApproval,
+		Message: v1beta1.MsgInstancePendingApproval,
+	})
+	return false, r.updateDBaaSObjectStatus(ctx, instance)
+}
+
+// getInstanceApproval returns the approval recorded by the approval annotations or by a DBaaSInstanceApproval,
+// or nil if the instance is not approved
+func (r *DBaaSInstanceReconciler) getInstanceApproval(ctx context.Context, instance *v1beta1.DBaaSInstance) (*v1beta1.DBaaSInstanceApprovalInfo, error) {
+	if approver := instance.Annotations[v1beta1.InstanceApprovedByAnnotation]; len(approver) > 0 {
+		if approvalTime, err := time.Parse(time.RFC3339, instance.Annotations[v1beta1.InstanceApprovedAtAnnotation]); err == nil {
+			return &v1beta1.DBaaSInstanceApprovalInfo{Approver: approver, ApprovalTime: metav1.NewTime(approvalTime)}, nil
+		}
+	}
+	var approvalList v1beta1.DBaaSInstanceApprovalList
+	if err := r.List(ctx, &approvalList, client.InNamespace(instance.Namespace)); err != nil {
+		return nil, err
+	}
+	for i := range approvalList.Items {
+		approval := &approvalList.Items[i]
+		// an approval only applies to the instance it was created for, not to an instance recreated with the same name
+		if approval.Spec.InstanceName == instance.Name && approval.Status.InstanceUID == instance.UID && len(approval.Status.Approver) > 0 {
+			return approval.Status.DBaaSInstanceApprovalInfo.DeepCopy(), nil
+		}
+	}
+	return nil, nil
+}
+
+// approvalInstanceRequests enqueues the instance of a DBaaSInstanceApproval
+func approvalInstanceRequests(o client.Object) []reconcile.Request {
+	approval, ok := o.(*v1beta1.DBaaSInstanceApproval)
+	if !ok || len(approval.Spec.InstanceName) == 0 {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: approval.Spec.InstanceName, Namespace: approval.Namespace}}}
+}
@@ -24,13 +24,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/metrics"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/providerapi"
 )
 
 // DBaaSInstanceReconciler reconciles a DBaaSInstance object
@@ -73,6 +77,30 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		event = metrics.LabelEventValueCreate
 	}
 
+	if instance.DeletionTimestamp != nil {
+		dependents, err := r.getInstanceDependents(ctx, &instance)
+		if err != nil {
+			logger.Error(err, "Error listing the dependent objects of the DBaaS Instance")
+			return ctrl.Result{}, err
+		}
+		if err := r.reconcileDependentsDeletion(ctx, &instance, instance.Spec.DeletionPolicy, dependents, &instance.Status.Conditions, logger); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Instance resource modified, retry deleting", "DBaaS Instance", instance)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error deleting the DBaaS Instance", "DBaaS Instance", instance)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if err := r.addDependentsFinalizer(ctx, &instance); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error adding the finalizer to the DBaaS Instance", "DBaaS Instance", instance)
+		return ctrl.Result{}, err
+	}
+
 	if inventory, validNS, provision, err := r.checkInventory(ctx, instance.Spec.InventoryRef, &instance, func(reason string, message string) {
 		cond := metav1.Condition{
 			Type:    v1beta1.DBaaSInstanceReadyType,
@@ -89,19 +117,25 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	} else if !provision {
 		return ctrl.Result{}, nil
+	} else if approved, err := r.checkApproval(ctx, &instance, inventory, logger); err != nil {
+		logger.Error(err, "Error checking the approval of the DBaaS Instance")
+		return ctrl.Result{}, err
+	} else if !approved {
+		return ctrl.Result{}, nil
 	} else {
 		provider, err := r.getDBaaSProvider(ctx, inventory.Spec.ProviderRef.Name)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-		specV1alpha1 := &v1alpha1.DBaaSInstanceSpec{}
-		if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
-			// Convert instance.Spec to v1alpha1 format
-			err := specV1alpha1.ConvertFrom(&instance.Spec)
-			if err != nil {
-				logger.Error(err, "Failed to convert instance.Spec to v1alpha1 format")
-				return ctrl.Result{}, err
-			}
+		converter, err := providerapi.ForProvider(provider)
+		if err != nil {
+			logger.Error(err, "Error converting the DBaaS Instance for the provider")
+			return ctrl.Result{}, err
+		}
+		providerSpec, err := converter.InstanceSpec(&instance.Spec)
+		if err != nil {
+			logger.Error(err, "Failed to convert instance.Spec to the provider API version")
+			return ctrl.Result{}, err
 		}
 		result, err := r.reconcileProviderResource(ctx,
 			inventory.Spec.ProviderRef.Name,
@@ -110,30 +144,11 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				return provider.Spec.InstanceKind
 			},
 			func() interface{} {
-				if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
-					spec := &v1alpha1.DBaaSInstanceSpec{}
-					if err = spec.ConvertFrom(&instance.Spec); err != nil {
-						logger.Error(err, "Failed to convert the Inventory spec to v1alpha1 provider object spec")
-					}
-					return spec
-				}
-				return instance.Spec.DeepCopy()
-			},
-			func() interface{} {
-				if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
-					return &v1alpha1.DBaaSProviderInstance{}
-				}
-				return &v1beta1.DBaaSProviderInstance{}
+				return providerSpec
 			},
+			converter.NewInstance,
 			func(i interface{}) metav1.Condition {
-				if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
-					providerInsV1alpha1 := i.(*v1alpha1.DBaaSProviderInstance)
-					providerInsV1beta1 := &v1beta1.DBaaSProviderInstance{}
-					providerInsV1alpha1.Status.ConvertTo(&providerInsV1beta1.Status)
-					return mergeInstanceStatus(&instance, providerInsV1beta1)
-				}
-				providerIns := i.(*v1beta1.DBaaSProviderInstance)
-				return mergeInstanceStatus(&instance, providerIns)
+				return mergeInstanceStatus(&instance, converter.ConvertInstance(i))
 			},
 			func() *[]metav1.Condition {
 				return &instance.Status.Conditions
@@ -151,9 +166,18 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSInstanceReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
-	return ctrl.NewControllerManagedBy(mgr).
+	return r.policyWatches(ctrl.NewControllerManagedBy(mgr), func() client.ObjectList {
+		return &v1beta1.DBaaSInstanceList{}
+	}).
 		For(&v1beta1.DBaaSInstance{}).
 		Watches(&source.Kind{Type: &v1beta1.DBaaSInstance{}}, &EventHandlerWithDelete{Controller: r}).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSConnection{}}, handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			if ref := getDatabaseServiceRef(o.(*v1beta1.DBaaSConnection)); ref != nil {
+				return []reconcile.Request{{NamespacedName: *ref}}
+			}
+			return nil
+		}), builder.WithPredicates(deleteEventPredicate)).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInstanceApproval{}}, handler.EnqueueRequestsFromMapFunc(approvalInstanceRequests)).
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 2},
 		).
@@ -162,7 +186,10 @@ func (r *DBaaSInstanceReconciler) SetupWithManager(mgr ctrl.Manager) (controller
 
 // mergeInstanceStatus: merge the status from DBaaSProviderInstance into the current DBaaSInstance status
 func mergeInstanceStatus(instance *v1beta1.DBaaSInstance, providerInst *v1beta1.DBaaSProviderInstance) metav1.Condition {
+	// the approval is recorded by the DBaaS Operator, not by the provider
+	approval := instance.Status.Approval
 	providerInst.Status.DeepCopyInto(&instance.Status)
+	instance.Status.Approval = approval
 	if len(instance.Status.Phase) == 0 {
 		instance.Status.Phase = v1beta1.InstancePhaseUnknown
 	}
@@ -17,11 +17,19 @@ limitations under the License.
 package controllers
 
 import (
+	"time"
+
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
 )
@@ -316,6 +324,136 @@ var _ = Describe("DBaaSInstance controller - nominal", func() {
 	})
 })
 
+var _ = Describe("DBaaSInstance controller - approvals", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(crunchyProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1beta1.Ready))
+
+	inventoryRefName := "test-inventory-approval"
+	createdDBaaSInventory := &v1beta1.DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      inventoryRefName,
+			Namespace: testNamespace,
+		},
+		Spec: v1beta1.DBaaSOperatorInventorySpec{
+			ProviderRef: v1beta1.NamespacedName{
+				Name: testProviderName,
+			},
+			Policy: &v1beta1.DBaaSInventoryPolicy{
+				RequireApproval: pointer.Bool(true),
+			},
+			DBaaSInventorySpec: v1beta1.DBaaSInventorySpec{
+				CredentialsRef: &v1beta1.LocalObjectReference{
+					Name: testSecret.Name,
+				},
+			},
+		},
+	}
+	providerInventoryStatus := &v1beta1.DBaaSInventoryStatus{
+		DatabaseServices: []v1beta1.DatabaseService{
+			{
+				ServiceID:   "testInstanceID",
+				ServiceName: "testInstance",
+			},
+		},
+		Conditions: []metav1.Condition{
+			{
+				Type:               "SpecSynced",
+				Status:             metav1.ConditionTrue,
+				Reason:             "SyncOK",
+				LastTransitionTime: metav1.Time{Time: getLastTransitionTimeForTest()},
+			},
+		},
+	}
+	BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, crunchyProvider.GetDBaaSAPIGroupVersion(), metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
+	AfterEach(assertResourceDeletion(createdDBaaSInventory))
+
+	newInstance := func(name string) (*v1beta1.DBaaSInstance, *v1beta1.DBaaSInstanceSpec) {
+		spec := &v1beta1.DBaaSInstanceSpec{
+			InventoryRef: v1beta1.NamespacedName{
+				Name:      inventoryRefName,
+				Namespace: testNamespace,
+			},
+			ProvisioningParameters: map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningName:          name,
+				v1beta1.ProvisioningCloudProvider: "aws",
+				v1beta1.ProvisioningRegions:       "test-region",
+				v1beta1.ProvisioningPlan:          v1beta1.ProvisioningPlanFreeTrial,
+			},
+		}
+		return &v1beta1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+			},
+			Spec: *spec,
+		}, spec
+	}
+
+	Context("after creating DBaaSInstance approved by a DBaaSInstanceApproval", func() {
+		createdDBaaSInstance, DBaaSInstanceSpec := newInstance("test-instance-approval")
+		approval := &v1beta1.DBaaSInstanceApproval{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-approval",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSInstanceApprovalSpec{
+				InstanceName: createdDBaaSInstance.Name,
+			},
+			// the status is set by the approval webhook, which is not running in the test environment
+			Status: v1beta1.DBaaSInstanceApprovalStatus{
+				DBaaSInstanceApprovalInfo: v1beta1.DBaaSInstanceApprovalInfo{
+					Approver:     "test-approver",
+					ApprovalTime: metav1.Now(),
+				},
+			},
+		}
+		staleApproval := approval.DeepCopy()
+		staleApproval.Name = "test-instance-approval-stale"
+		staleApproval.Status.InstanceUID = "test-deleted-instance-uid"
+		BeforeEach(assertResourceCreation(createdDBaaSInstance))
+		AfterEach(assertResourceDeletion(createdDBaaSInstance))
+		AfterEach(assertResourceDeletion(approval))
+		AfterEach(assertResourceDeletion(staleApproval))
+
+		It("should wait for the approval before creating the provider instance", func() {
+			assertInstancePendingApproval(createdDBaaSInstance)()
+
+			By("ignoring the approval of a previous instance with the same name")
+			Expect(dRec.Create(ctx, staleApproval)).Should(Succeed())
+			assertInstancePendingApproval(createdDBaaSInstance)()
+
+			By("approving the instance")
+			approval.Status.InstanceUID = createdDBaaSInstance.UID
+			Expect(dRec.Create(ctx, approval)).Should(Succeed())
+			assertProviderResourceCreated(createdDBaaSInstance, crunchyProvider.GetDBaaSAPIGroupVersion(), testInstanceKind, DBaaSInstanceSpec)()
+			assertInstanceApproval(createdDBaaSInstance, "test-approver")()
+		})
+	})
+
+	Context("after creating DBaaSInstance approved by the approval annotations", func() {
+		createdDBaaSInstance, DBaaSInstanceSpec := newInstance("test-instance-approval-annotations")
+		BeforeEach(assertResourceCreation(createdDBaaSInstance))
+		AfterEach(assertResourceDeletion(createdDBaaSInstance))
+
+		It("should wait for the approval before creating the provider instance", func() {
+			assertInstancePendingApproval(createdDBaaSInstance)()
+
+			By("approving the instance")
+			instance := &v1beta1.DBaaSInstance{}
+			Expect(dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), instance)).Should(Succeed())
+			instance.Annotations = map[string]string{
+				v1beta1.InstanceApprovedByAnnotation: "test-approver",
+				v1beta1.InstanceApprovedAtAnnotation: time.Now().UTC().Format(time.RFC3339),
+			}
+			Expect(dRec.Update(ctx, instance)).Should(Succeed())
+			assertProviderResourceCreated(createdDBaaSInstance, crunchyProvider.GetDBaaSAPIGroupVersion(), testInstanceKind, DBaaSInstanceSpec)()
+			assertInstanceApproval(createdDBaaSInstance, "test-approver")()
+		})
+	})
+})
+
 var _ = Describe("DBaaSInstance controller - valid dev namespaces", func() {
 	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
 	BeforeEach(assertResourceCreationIfNotExists(crunchyProvider))
@@ -742,3 +880,57 @@ var _ = Describe("DBaaSInstance controller - nominal", func() {
 		})
 	})
 })
+
+var _ = Describe("DBaaSInstance approvals", func() {
+	isTrue := true
+	isFalse := false
+
+	DescribeTable("checking if the instances require an approval",
+		func(inventoryPolicy *v1beta1.DBaaSInventoryPolicy, policyRequireApproval *bool, expected bool) {
+			inventory := &v1beta1.DBaaSInventory{Spec: v1beta1.DBaaSOperatorInventorySpec{Policy: inventoryPolicy}}
+			policy := &v1beta1.DBaaSPolicy{Spec: v1beta1.DBaaSPolicySpec{
+				DBaaSInventoryPolicy: v1beta1.DBaaSInventoryPolicy{RequireApproval: policyRequireApproval},
+			}}
+			Expect(requiresApproval(v1beta1.GetEffectivePolicy(nil, policy, inventory))).Should(Equal(expected))
+		},
+		Entry("not by default", nil, nil, false),
+		Entry("by policy", nil, &isTrue, true),
+		Entry("by inventory", &v1beta1.DBaaSInventoryPolicy{RequireApproval: &isTrue}, nil, true),
+		Entry("not if the inventory overrides the policy", &v1beta1.DBaaSInventoryPolicy{RequireApproval: &isFalse}, &isTrue, false),
+	)
+
+	It("should keep the approval when merging the provider status", func() {
+		approval := &v1beta1.DBaaSInstanceApprovalInfo{Approver: "admin", ApprovalTime: metav1.Now()}
+		instance := &v1beta1.DBaaSInstance{Status: v1beta1.DBaaSInstanceStatus{Approval: approval}}
+		mergeInstanceStatus(instance, &v1beta1.DBaaSProviderInstance{Status: v1beta1.DBaaSInstanceStatus{Phase: v1beta1.InstancePhaseCreating}})
+		Expect(instance.Status.Phase).Should(Equal(v1beta1.InstancePhaseCreating))
+		Expect(instance.Status.Approval).Should(Equal(approval))
+	})
+})
+
+func assertInstancePendingApproval(instance *v1beta1.DBaaSInstance) func() {
+	return func() {
+		assertDBaaSResourceStatusUpdated(instance, metav1.ConditionFalse, v1beta1.DBaaSInstancePendingApproval)()
+		Expect(instance.Status.Phase).Should(Equal(v1beta1.InstancePhasePendingApproval))
+
+		By("checking the provider instance is not created")
+		providerInstance := &unstructured.Unstructured{}
+		providerInstance.SetGroupVersionKind(crunchyProvider.GetDBaaSAPIGroupVersion().WithKind(testInstanceKind))
+		Consistently(func() bool {
+			return errors.IsNotFound(dRec.Get(ctx, client.ObjectKeyFromObject(instance), providerInstance))
+		}).Should(BeTrue())
+	}
+}
+
+func assertInstanceApproval(instance *v1beta1.DBaaSInstance, approver string) func() {
+	return func() {
+		By("checking the approval is recorded in the DBaaSInstance status")
+		Eventually(func() string {
+			approvedInstance := &v1beta1.DBaaSInstance{}
+			if err := dRec.Get(ctx, client.ObjectKeyFromObject(instance), approvedInstance); err != nil || approvedInstance.Status.Approval == nil {
+				return ""
+			}
+			return approvedInstance.Status.Approval.Approver
+		}, timeout).Should(Equal(approver))
+	}
+}
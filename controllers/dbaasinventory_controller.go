@@ -19,17 +19,23 @@ package controllers
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/metrics"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/providerapi"
 )
 
 // DBaaSInventoryReconciler reconciles a DBaaSInventory object
@@ -72,13 +78,44 @@ func (r *DBaaSInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		event = metrics.LabelEventValueCreate
 	}
 
+	if inventory.DeletionTimestamp != nil {
+		dependents, err := r.getInventoryDependents(ctx, &inventory)
+		if err != nil {
+			logger.Error(err, "Error listing the dependent objects of the DBaaS Inventory")
+			return ctrl.Result{}, err
+		}
+		if err := r.reconcileDependentsDeletion(ctx, &inventory, inventory.Spec.DeletionPolicy, dependents, &inventory.Status.Conditions, logger); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Inventory resource modified, retry deleting", "DBaaS Inventory", inventory)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error deleting the DBaaS Inventory", "DBaaS Inventory", inventory)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if err := r.addDependentsFinalizer(ctx, &inventory); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error adding the finalizer to the DBaaS Inventory", "DBaaS Inventory", inventory)
+		return ctrl.Result{}, err
+	}
+
 	policyList, err := r.policyListByNS(ctx, req.Namespace)
 	if err != nil {
 		logger.Error(err, "unable to list policies")
 		metricLabelErrCdValue = metrics.LabelErrorCdValueUnableToListPolicies
 		return ctrl.Result{}, err
 	}
-	activePolicy := getActivePolicy(policyList)
+	activePolicy := v1beta1.GetActivePolicy(policyList.Items)
+	policyStatus, err := r.getInventoryPolicyStatus(ctx, &inventory, activePolicy)
+	if err != nil {
+		logger.Error(err, "Error resolving the policy of the DBaaS Inventory")
+		metricLabelErrCdValue = metrics.LabelErrorCdValueErrorResolvingInventoryPolicy
+		return ctrl.Result{}, err
+	}
+	inventory.Status.Policy = policyStatus
 	if activePolicy == nil {
 		logger.Info("No DBaaSPolicy found for the target namespace", "Namespace", req.Namespace)
 		cond := metav1.Condition{
@@ -115,6 +152,15 @@ func (r *DBaaSInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	converter, err := providerapi.ForProvider(provider)
+	if err != nil {
+		logger.Error(err, "Error converting the DBaaS Inventory for the provider")
+		return ctrl.Result{}, err
+	}
+	providerSpec, err := converter.InventorySpec(&inventory.Spec)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 
 	//
 	// Provider Inventory
@@ -126,28 +172,11 @@ func (r *DBaaSInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return provider.Spec.InventoryKind
 		},
 		func() interface{} {
-			if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
-				spec := &v1alpha1.DBaaSOperatorInventorySpec{}
-				spec.ConvertFrom(&inventory.Spec)
-				return spec
-			}
-			return inventory.Spec.DeepCopy()
-		},
-		func() interface{} {
-			if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
-				return &v1alpha1.DBaaSProviderInventory{}
-			}
-			return &v1beta1.DBaaSProviderInventory{}
+			return providerSpec
 		},
+		converter.NewInventory,
 		func(i interface{}) metav1.Condition {
-			if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
-				providerInvV1alpha1 := i.(*v1alpha1.DBaaSProviderInventory)
-				providerInvV1beta1 := &v1beta1.DBaaSProviderInventory{}
-				providerInvV1alpha1.Status.ConvertTo(&providerInvV1beta1.Status)
-				return mergeInventoryStatus(&inventory, providerInvV1beta1)
-			}
-			providerInv := i.(*v1beta1.DBaaSProviderInventory)
-			return mergeInventoryStatus(&inventory, providerInv)
+			return mergeInventoryStatus(&inventory, converter.ConvertInventory(i))
 		},
 		func() *[]metav1.Condition {
 			return &inventory.Status.Conditions
@@ -162,15 +191,103 @@ func (r *DBaaSInventoryReconciler) SetupWithManager(mgr ctrl.Manager) (controlle
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.DBaaSInventory{}).
 		Watches(&source.Kind{Type: &v1beta1.DBaaSInventory{}}, &EventHandlerWithDelete{Controller: r}).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSConnection{}}, handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: inventoryRefName(o.(*v1beta1.DBaaSConnection).Spec.InventoryRef, o.GetNamespace())}}
+		}), builder.WithPredicates(deleteEventPredicate)).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInstance{}}, handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: inventoryRefName(o.(*v1beta1.DBaaSInstance).Spec.InventoryRef, o.GetNamespace())}}
+		}), builder.WithPredicates(deleteEventPredicate)).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSPolicy{}}, handler.EnqueueRequestsFromMapFunc(r.policyInventoryRequests)).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSClusterPolicy{}}, handler.EnqueueRequestsFromMapFunc(r.clusterPolicyInventoryRequests)).
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(r.namespaceInventoryRequests),
+			builder.WithPredicates(predicate.LabelChangedPredicate{})).
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 2},
 		).
 		Build(r)
 }
 
+// policyInventoryRequests enqueues the inventories of the namespace of a DBaaSPolicy, to update their resolved policy
+func (r *DBaaSInventoryReconciler) policyInventoryRequests(o client.Object) []reconcile.Request {
+	var inventoryList v1beta1.DBaaSInventoryList
+	if err := r.List(context.Background(), &inventoryList, client.InNamespace(o.GetNamespace())); err != nil {
+		ctrl.Log.Error(err, "Error listing DBaaS Inventories for the DBaaS Policy change", "policy", o.GetName())
+		return nil
+	}
+	return inventoryRequests(inventoryList.Items)
+}
+
+// clusterPolicyInventoryRequests enqueues all the inventories, to update their resolved policy
+func (r *DBaaSInventoryReconciler) clusterPolicyInventoryRequests(o client.Object) []reconcile.Request {
+	var inventoryList v1beta1.DBaaSInventoryList
+	if err := r.List(context.Background(), &inventoryList); err != nil {
+		ctrl.Log.Error(err, "Error listing DBaaS Inventories for the DBaaS Cluster Policy change", "clusterPolicy", o.GetName())
+		return nil
+	}
+	return inventoryRequests(inventoryList.Items)
+}
+
+// namespaceInventoryRequests enqueues the inventories selecting their connection namespaces by labels, or all the
+// inventories when a cluster policy limits the connection namespaces by labels, to update the namespaces allowed to
+// connect when a namespace is created, deleted, or labeled. The inventories and policies selecting namespaces by labels
+// are listed with the NsSelectorIndex field index.
+func (r *DBaaSInventoryReconciler) namespaceInventoryRequests(o client.Object) []reconcile.Request {
+	ctx := context.Background()
+	var clusterPolicyList v1beta1.DBaaSClusterPolicyList
+	if err := r.List(ctx, &clusterPolicyList); err != nil {
+		ctrl.Log.Error(err, "Error listing DBaaS Cluster Policies for the namespace change", "namespace", o.GetName())
+		return nil
+	}
+	for i := range clusterPolicyList.Items {
+		if connections := clusterPolicyList.Items[i].Spec.Connections; connections != nil && connections.NsSelector != nil {
+			// the connection namespaces of all the inventories are limited by labels
+			return r.clusterPolicyInventoryRequests(o)
+		}
+	}
+
+	var inventoryList v1beta1.DBaaSInventoryList
+	if err := r.List(ctx, &inventoryList, client.MatchingFields{v1beta1.NsSelectorIndex: "true"}); err != nil {
+		ctrl.Log.Error(err, "Error listing DBaaS Inventories for the namespace change", "namespace", o.GetName())
+		return nil
+	}
+	inventories := inventoryList.Items
+
+	var policyList v1beta1.DBaaSPolicyList
+	if err := r.List(ctx, &policyList, client.MatchingFields{v1beta1.NsSelectorIndex: "true"}); err != nil {
+		ctrl.Log.Error(err, "Error listing DBaaS Policies for the namespace change", "namespace", o.GetName())
+		return nil
+	}
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if !apimeta.IsStatusConditionTrue(policy.Status.Conditions, v1beta1.DBaaSPolicyReadyType) {
+			continue
+		}
+		var nsInventoryList v1beta1.DBaaSInventoryList
+		if err := r.List(ctx, &nsInventoryList, client.InNamespace(policy.Namespace)); err != nil {
+			ctrl.Log.Error(err, "Error listing DBaaS Inventories for the namespace change", "namespace", o.GetName())
+			return nil
+		}
+		for _, inventory := range nsInventoryList.Items {
+			// the policy of an inventory overrides the policy of its namespace
+			if inventory.Spec.Policy == nil {
+				inventories = append(inventories, inventory)
+			}
+		}
+	}
+	return inventoryRequests(inventories)
+}
+
+func inventoryRequests(inventories []v1beta1.DBaaSInventory) []reconcile.Request {
+	var requests []reconcile.Request
+	for i := range inventories {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&inventories[i])})
+	}
+	return requests
+}
+
 // mergeInventoryStatus: merge the status from DBaaSProviderInventory into the current DBaaSInventory status
 func mergeInventoryStatus(inv *v1beta1.DBaaSInventory, providerInv *v1beta1.DBaaSProviderInventory) metav1.Condition {
-	providerInv.Status.DeepCopyInto(&inv.Status)
+	providerInv.Status.DeepCopyInto(&inv.Status.DBaaSInventoryStatus)
 	// Update inventory status condition (type: DBaaSInventoryReadyType) based on the provider status
 	specSync := apimeta.FindStatusCondition(providerInv.Status.Conditions, v1beta1.DBaaSInventoryProviderSyncType)
 	if specSync != nil && specSync.Status == metav1.ConditionTrue {
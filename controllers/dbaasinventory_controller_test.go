@@ -21,8 +21,11 @@ import (
 	. "github.com/onsi/gomega"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
@@ -123,6 +126,20 @@ var _ = Describe("DBaaSInventory controller - nominal", func() {
 			AfterEach(assertResourceDeletion(createdDBaaSInventory))
 
 			It("should create a provider inventory", assertProviderResourceCreated(createdDBaaSInventory, crunchyProvider.GetDBaaSAPIGroupVersion(), testInventoryKind, DBaaSInventorySpec))
+			It("should report the resolved policy", func() {
+				Eventually(func() *v1beta1.DBaaSInventoryPolicyStatus {
+					getInventory := v1beta1.DBaaSInventory{}
+					if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInventory), &getInventory); err != nil {
+						return nil
+					}
+					return getInventory.Status.Policy
+				}, timeout).Should(Equal(&v1beta1.DBaaSInventoryPolicyStatus{
+					Source:               v1beta1.InventoryPolicySourcePolicy,
+					PolicyName:           defaultPolicy.Name,
+					ProvisioningAllowed:  true,
+					ConnectionNamespaces: []string{"*"},
+				}))
+			})
 
 			Context("when updating provider inventory status", func() {
 				lastTransitionTime := getLastTransitionTimeForTest()
@@ -257,3 +274,109 @@ var _ = Describe("DBaaSInventory controller for v1alpha1 provider - nominal", fu
 		})
 	})
 })
+
+var _ = Describe("DBaaSInventory controller - deletion", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(crunchyProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1beta1.Ready))
+
+	newInventory := func(name string, deletionPolicy v1beta1.DeletionPolicy) *v1beta1.DBaaSInventory {
+		return &v1beta1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1beta1.NamespacedName{
+					Name: v1beta1.CrunchyBridgeRegistration,
+				},
+				DBaaSInventorySpec: v1beta1.DBaaSInventorySpec{
+					CredentialsRef: &v1beta1.LocalObjectReference{
+						Name: testSecret.Name,
+					},
+				},
+				DeletionPolicy: deletionPolicy,
+			},
+		}
+	}
+	newConnection := func(name string, inventory *v1beta1.DBaaSInventory) *v1beta1.DBaaSConnection {
+		return &v1beta1.DBaaSConnection{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSConnectionSpec{
+				InventoryRef: v1beta1.NamespacedName{
+					Name:      inventory.Name,
+					Namespace: inventory.Namespace,
+				},
+				DatabaseServiceID: "test-instanceID",
+			},
+		}
+	}
+	assertDeletionBlocked := func(inventory *v1beta1.DBaaSInventory, reason string) func() {
+		return func() {
+			Eventually(func() bool {
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(inventory), inventory); err != nil {
+					return false
+				}
+				cond := apimeta.FindStatusCondition(inventory.Status.Conditions, v1beta1.DBaaSDeletionBlockedType)
+				return inventory.DeletionTimestamp != nil && cond != nil && cond.Reason == reason
+			}, timeout).Should(BeTrue())
+		}
+	}
+
+	Context("with the default deletion policy", func() {
+		inventory := newInventory("test-inventory-deletion-block", "")
+		connection := newConnection("test-connection-deletion-block", inventory)
+		BeforeEach(assertResourceCreation(inventory))
+		BeforeEach(assertResourceCreation(connection))
+
+		It("should keep the inventory until the connection is deleted", func() {
+			Eventually(func() bool {
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(inventory), inventory); err != nil {
+					return false
+				}
+				return controllerutil.ContainsFinalizer(inventory, v1beta1.DBaaSDependentsFinalizer)
+			}, timeout).Should(BeTrue())
+
+			Expect(dRec.Delete(ctx, inventory)).Should(Succeed())
+			assertDeletionBlocked(inventory, v1beta1.DBaaSDependentsExist)()
+			Expect(apimeta.FindStatusCondition(inventory.Status.Conditions, v1beta1.DBaaSDeletionBlockedType).Message).
+				Should(ContainSubstring("DBaaSConnection " + testNamespace + "/" + connection.Name))
+
+			assertResourceDeletion(connection)()
+			Eventually(func() bool {
+				return errors.IsNotFound(dRec.Get(ctx, client.ObjectKeyFromObject(inventory), inventory))
+			}, timeout).Should(BeTrue())
+		})
+	})
+
+	Context("with the Cascade deletion policy", func() {
+		inventory := newInventory("test-inventory-deletion-cascade", v1beta1.DeletionPolicyCascade)
+		connection := newConnection("test-connection-deletion-cascade", inventory)
+		BeforeEach(assertResourceCreation(inventory))
+		BeforeEach(assertResourceCreation(connection))
+
+		It("should delete the connection along with the inventory", func() {
+			assertResourceDeletion(inventory)()
+			Eventually(func() bool {
+				return errors.IsNotFound(dRec.Get(ctx, client.ObjectKeyFromObject(connection), connection))
+			}, timeout).Should(BeTrue())
+		})
+	})
+
+	Context("with the Orphan deletion policy", func() {
+		inventory := newInventory("test-inventory-deletion-orphan", v1beta1.DeletionPolicyOrphan)
+		connection := newConnection("test-connection-deletion-orphan", inventory)
+		BeforeEach(assertResourceCreation(inventory))
+		BeforeEach(assertResourceCreation(connection))
+		AfterEach(assertResourceDeletion(connection))
+
+		It("should delete the inventory and keep the connection", func() {
+			assertResourceDeletion(inventory)()
+			Expect(dRec.Get(ctx, client.ObjectKeyFromObject(connection), connection)).Should(Succeed())
+		})
+	})
+})
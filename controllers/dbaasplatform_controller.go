@@ -22,6 +22,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
@@ -30,6 +31,7 @@ import (
 	metrics "github.com/RHEcosystemAppEng/dbaas-operator/controllers/metrics"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers/consoleplugin"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers/manifestsinstallation"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers/providersinstallation"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers/quickstartinstallation"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/util"
@@ -44,8 +46,10 @@ import (
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -72,6 +76,9 @@ const (
 	RequeueDelayError   = 5 * time.Second
 )
 
+// platformCRName is the name of the DBaaSPlatform created in the install namespace
+const platformCRName = "dbaas-platform"
+
 // DBaaSPlatformReconciler reconciles a DBaaSPlatform object
 type DBaaSPlatformReconciler struct {
 	*DBaaSReconciler
@@ -85,6 +92,7 @@ type DBaaSPlatformReconciler struct {
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
 //+kubebuilder:rbac:groups=operators.coreos.com,resources=catalogsources;operatorgroups,verbs=get;list;create;update;watch
 //+kubebuilder:rbac:groups=operators.coreos.com,resources=subscriptions,verbs=get;list;create;update;watch;delete
+//+kubebuilder:rbac:groups=operators.coreos.com,resources=installplans,verbs=get;list;watch;update
 //+kubebuilder:rbac:groups=operators.coreos.com,resources=clusterserviceversions,verbs=get;update;delete
 //+kubebuilder:rbac:groups=operators.coreos.com,resources=clusterserviceversions/finalizers,verbs=update
 //+kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;statefulsets,verbs=get;list;create;update;watch;delete
@@ -125,6 +133,34 @@ func (r *DBaaSPlatformReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	// the platform is deleted with the CSV of the operator, the dependents finalizers would block the deletion
+	// of the inventories and the instances once the operator is uninstalled
+	if cr.DeletionTimestamp != nil {
+		if controllerutil.ContainsFinalizer(cr, v1beta1.DBaaSPlatformFinalizer) {
+			if err = r.removeDependentsFinalizers(ctx); err != nil {
+				logger.Error(err, "Error removing the dependents finalizers")
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(cr, v1beta1.DBaaSPlatformFinalizer)
+			if err = r.Update(ctx, cr); err != nil {
+				logger.Error(err, "Error removing the DBaaSPlatform finalizer")
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+	} else if controllerutil.AddFinalizer(cr, v1beta1.DBaaSPlatformFinalizer) {
+		if err = r.Update(ctx, cr); err != nil {
+			logger.Error(err, "Error adding the DBaaSPlatform finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	capabilities, err := reconcilers.DetectCapabilities(r.Client.RESTMapper())
+	if err != nil {
+		logger.Error(err, "Error detecting the cluster capabilities")
+		return ctrl.Result{}, err
+	}
+
 	// OCPBUGS-4991 - temporary fix until https://github.com/operator-framework/operator-lifecycle-manager/pull/2912 makes it to a release
 	if err = r.fixConversionWebhooks(ctx); err != nil {
 		logger.Error(err, "Error related to conversion webhook setup")
@@ -147,67 +183,60 @@ func (r *DBaaSPlatformReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 	var finished = true
 
-	var platforms map[v1beta1.PlatformName]v1beta1.PlatformConfig
-
-	r.setOpenShiftInstallationInfo(ctx, err, logger, cr)
-
-	if cr.DeletionTimestamp == nil {
-		platforms = reconcilers.InstallationPlatforms
-	}
+	r.setOpenShiftInstallationInfo(ctx, capabilities, logger, cr)
 
 	nextStatus := cr.Status.DeepCopy()
-	nextPlatformStatus := v1beta1.PlatformStatus{}
-	for platform, platformConfig := range platforms {
-		nextPlatformStatus.PlatformName = platform
-		reconciler := r.getReconcilerForPlatform(platformConfig)
-		if reconciler != nil {
-			var status v1beta1.PlatformInstlnStatus
-			var err error
-
-			if cr.DeletionTimestamp == nil {
-				status, err = reconciler.Reconcile(ctx, cr)
-				metrics.SetPlatformStatusMetric(platform, status, platformConfig.CSV)
-
-			} else {
-				status, err = reconciler.Cleanup(ctx, cr)
-			}
+	nextStatus.Capabilities = capabilities.List()
+	if cr.DeletionTimestamp == nil {
+		platforms, err := reconcilers.GetInstallationPlatforms(cr.Spec.Platforms)
+		var levels [][]v1beta1.PlatformName
+		if err == nil {
+			levels, err = reconcilers.GetInstallationOrder(platforms)
+		}
+		if err != nil {
+			logger.Error(err, "Invalid DBaaS platform settings")
+			setStatusCondition(&nextStatus.Conditions, v1beta1.DBaaSPlatformReadyType, metav1.ConditionFalse, v1beta1.InvalidPlatformSettings, err.Error())
+			return r.updateStatus(cr, nextStatus)
+		}
 
-			if err != nil {
-				nextPlatformStatus.LastMessage = err.Error()
-				return ctrl.Result{}, err
-			}
-			// Reset error message when everything went well
-			nextPlatformStatus.LastMessage = ""
-			nextPlatformStatus.PlatformStatus = status
-			setStatusPlatform(&nextStatus.PlatformsStatus, nextPlatformStatus)
+		if finished, err = r.cleanupDisabledPlatforms(ctx, cr, platforms, capabilities, nextStatus); err != nil {
+			logger.Error(err, "Error removing the disabled platforms")
+			return ctrl.Result{}, err
+		}
 
-			// If a platform is not complete, do not continue with the next
-			if status != v1beta1.ResultSuccess {
-				if cr.DeletionTimestamp == nil {
-					metrics.PlatformStackInstallationMetric(cr, r.operatorNameVersion, execution)
-					logger.Info("DBaaS platform stack install in progress", "working platform", platform)
-					setStatusCondition(&nextStatus.Conditions, v1beta1.DBaaSPlatformReadyType, metav1.ConditionFalse, v1beta1.InstallationInprogress, "DBaaS platform stack install in progress")
-				} else {
-					logger.Info("DBaaS platform stack cleanup in progress", "working platform", platform)
-					setStatusCondition(&nextStatus.Conditions, v1beta1.DBaaSPlatformReadyType, metav1.ConditionUnknown, v1beta1.InstallationCleanup, "DBaaS platform stack cleanup in progress")
-				}
-				finished = false
-				break
+		unreachable := r.verifyPlatformImages(ctx, cr, platforms, nextStatus)
+		installed, installErr := r.installPlatforms(ctx, cr, platforms, levels, capabilities, unreachable, nextStatus)
+		if !installed {
+			finished = false
+			metrics.PlatformStackInstallationMetric(cr, r.operatorNameVersion, execution)
+			setStatusCondition(&nextStatus.Conditions, v1beta1.DBaaSPlatformReadyType, metav1.ConditionFalse, v1beta1.InstallationInprogress, "DBaaS platform stack install in progress")
+		}
+		if installErr != nil {
+			if _, err := r.updateStatus(cr, nextStatus); err != nil {
+				logger.Error(err, "Error updating the DBaaSPlatform status")
 			}
+			return ctrl.Result{}, installErr
 		}
 	}
-	if cr.DeletionTimestamp == nil && finished && !r.installComplete {
-		r.installComplete = true
+	if cr.DeletionTimestamp == nil && finished {
 		setStatusCondition(&nextStatus.Conditions, v1beta1.DBaaSPlatformReadyType, metav1.ConditionTrue, v1beta1.Ready, "DBaaS platform stack installation complete")
-		metrics.PlatformStackInstallationMetric(cr, r.operatorNameVersion, execution)
-		logger.Info("DBaaS platform stack installation complete")
+		if !r.installComplete {
+			r.installComplete = true
+			metrics.PlatformStackInstallationMetric(cr, r.operatorNameVersion, execution)
+			logger.Info("DBaaS platform stack installation complete")
+		}
 	}
 
 	return r.updateStatus(cr, nextStatus)
 }
 
-//setOpenShiftInstallationInfo sets the metrics for dbaas_version_info
-func (r *DBaaSPlatformReconciler) setOpenShiftInstallationInfo(ctx context.Context, err error, logger logr.Logger, cr *v1beta1.DBaaSPlatform) {
+//setOpenShiftInstallationInfo sets the metrics for dbaas_version_info, the OpenShift information is left empty on other clusters
+func (r *DBaaSPlatformReconciler) setOpenShiftInstallationInfo(ctx context.Context, capabilities reconcilers.Capabilities, logger logr.Logger, cr *v1beta1.DBaaSPlatform) {
+	if !capabilities.Has(v1beta1.CapabilityOpenShiftConfig) {
+		metrics.SetOpenShiftInstallationInfoMetric(r.operatorNameVersion, "", "", cr.CreationTimestamp.String(), "")
+		return
+	}
+
 	consoleURL, err := util.GetOpenshiftConsoleURL(ctx, r.Client)
 	if err != nil {
 		logger.Error(err, "Error in getting of openshift consoleURl")
@@ -227,11 +256,18 @@ func (r *DBaaSPlatformReconciler) setOpenShiftInstallationInfo(ctx context.Conte
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSPlatformReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// envVar set for all operators
+	capabilities, err := reconcilers.DetectCapabilities(mgr.GetRESTMapper())
+	if err != nil {
+		return err
+	}
+	// envVar set for all operators installed by OLM
 	operatorNameEnvVar, found := os.LookupEnv("OPERATOR_CONDITION_NAME")
 	if !found {
-		err := fmt.Errorf("OPERATOR_CONDITION_NAME must be set")
-		return err
+		if capabilities.Has(v1beta1.CapabilityOLM) {
+			err := fmt.Errorf("OPERATOR_CONDITION_NAME must be set")
+			return err
+		}
+		operatorNameEnvVar = "dbaas-operator"
 	}
 	r.operatorNameVersion = operatorNameEnvVar
 	// Creates a new managed install CR if it is not available
@@ -242,7 +278,7 @@ func (r *DBaaSPlatformReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if err := r.prepareRDSController(context.Background(), client); err != nil {
 		return err
 	}
-	if _, err := r.createPlatformCR(context.Background(), client); err != nil {
+	if _, err := r.createPlatformCR(context.Background(), client, capabilities); err != nil {
 		return err
 	}
 	return ctrl.NewControllerManagedBy(mgr).
@@ -250,7 +286,7 @@ func (r *DBaaSPlatformReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func (r *DBaaSPlatformReconciler) createPlatformCR(ctx context.Context, serverClient k8sclient.Client) (*v1beta1.DBaaSPlatform, error) {
+func (r *DBaaSPlatformReconciler) createPlatformCR(ctx context.Context, serverClient k8sclient.Client, capabilities reconcilers.Capabilities) (*v1beta1.DBaaSPlatform, error) {
 	namespace := r.InstallNamespace
 	dbaaSPlatformList := &v1beta1.DBaaSPlatformList{}
 	listOpts := []k8sclient.ListOption{
@@ -266,7 +302,7 @@ func (r *DBaaSPlatformReconciler) createPlatformCR(ctx context.Context, serverCl
 		syncPeriod := 180
 		cr = &v1beta1.DBaaSPlatform{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      "dbaas-platform",
+				Name:      platformCRName,
 				Namespace: strings.TrimSpace(namespace),
 				Labels:    map[string]string{"managed-by": "dbaas-operator"},
 			},
@@ -275,13 +311,16 @@ func (r *DBaaSPlatformReconciler) createPlatformCR(ctx context.Context, serverCl
 			},
 		}
 
-		owner, err := reconcilers.GetDBaaSOperatorCSV(ctx, namespace, r.operatorNameVersion, serverClient)
-		if err != nil {
-			return nil, fmt.Errorf("could not create dbaas platform intallation CR: %w", err)
-		}
-		err = ctrl.SetControllerReference(owner, cr, r.Scheme)
-		if err != nil {
-			return nil, fmt.Errorf("could not create dbaas platform intallation CR: %w", err)
+		// the CR is owned by the CSV of the operator, when installed by OLM
+		if capabilities.Has(v1beta1.CapabilityOLM) {
+			owner, err := reconcilers.GetDBaaSOperatorCSV(ctx, namespace, r.operatorNameVersion, serverClient)
+			if err != nil {
+				return nil, fmt.Errorf("could not create dbaas platform intallation CR: %w", err)
+			}
+			err = ctrl.SetControllerReference(owner, cr, r.Scheme)
+			if err != nil {
+				return nil, fmt.Errorf("could not create dbaas platform intallation CR: %w", err)
+			}
 		}
 
 		err = serverClient.Create(ctx, cr)
@@ -296,9 +335,175 @@ func (r *DBaaSPlatformReconciler) createPlatformCR(ctx context.Context, serverCl
 	return cr, nil
 }
 
-func (r *DBaaSPlatformReconciler) getReconcilerForPlatform(platformConfig v1beta1.PlatformConfig) reconcilers.PlatformReconciler {
+// installPlatforms installs the platforms level by level, the platforms of a level are installed in parallel.
+// A platform waits for its dependencies, and does not block the platforms that do not depend on it.
+// The platforms requiring capabilities that the cluster does not serve are skipped.
+func (r *DBaaSPlatformReconciler) installPlatforms(ctx context.Context, cr *v1beta1.DBaaSPlatform, platforms map[v1beta1.PlatformName]v1beta1.PlatformConfig,
+	levels [][]v1beta1.PlatformName, capabilities reconcilers.Capabilities, unreachable map[v1beta1.PlatformName]string, nextStatus *v1beta1.DBaaSPlatformStatus) (bool, error) {
+	type outcome struct {
+		status     v1beta1.PlatformInstlnStatus
+		err        error
+		message    string
+		details    v1beta1.PlatformStatus
+		reconciled bool
+	}
+	logger := log.FromContext(ctx)
+	finished := true
+	var errs []error
+	results := make(map[v1beta1.PlatformName]v1beta1.PlatformInstlnStatus, len(platforms))
+	for _, level := range levels {
+		outcomes := make(map[v1beta1.PlatformName]outcome, len(level))
+		var mutex sync.Mutex
+		var wg sync.WaitGroup
+		for _, platform := range level {
+			platformConfig := platforms[platform]
+			if reason := reconcilers.GetSkipReason(platformConfig, capabilities); len(reason) > 0 {
+				outcomes[platform] = outcome{status: v1beta1.ResultSkipped, message: reason}
+				continue
+			}
+			reconciler := r.getReconcilerForPlatform(platformConfig, capabilities)
+			if reconciler == nil {
+				results[platform] = v1beta1.ResultSuccess
+				continue
+			}
+			var waitingFor []string
+			for _, dependency := range reconcilers.GetInstalledDependencies(platformConfig, platforms) {
+				// the skipped platforms are not available on the cluster, the platforms depending on them are still installed
+				if results[dependency] != v1beta1.ResultSuccess && results[dependency] != v1beta1.ResultSkipped {
+					waitingFor = append(waitingFor, string(dependency))
+				}
+			}
+			if len(waitingFor) > 0 {
+				outcomes[platform] = outcome{status: v1beta1.ResultWaiting, message: fmt.Sprintf("waiting for %s", strings.Join(waitingFor, ", "))}
+				continue
+			}
+			if message, found := unreachable[platform]; found {
+				outcomes[platform] = outcome{status: v1beta1.ResultFailed, message: message}
+				continue
+			}
+			wg.Add(1)
+			go func(platform v1beta1.PlatformName, platformConfig v1beta1.PlatformConfig) {
+				defer wg.Done()
+				status, err := reconciler.Reconcile(ctx, cr)
+				metrics.SetPlatformStatusMetric(platform, status, platformConfig.CSV)
+				var details v1beta1.PlatformStatus
+				if reporter, ok := reconciler.(reconcilers.PlatformStatusReporter); ok {
+					if reportErr := reporter.ReportStatus(ctx, cr, &details); reportErr != nil {
+						logger.Error(reportErr, "Error reporting the DBaaS platform status", "platform", platform)
+					}
+				}
+				mutex.Lock()
+				defer mutex.Unlock()
+				outcomes[platform] = outcome{status: status, err: err, details: details, reconciled: true}
+			}(platform, platformConfig)
+		}
+		wg.Wait()
+
+		now := metav1.Now()
+		for _, platform := range level {
+			result, ok := outcomes[platform]
+			if !ok {
+				continue
+			}
+			nextPlatformStatus := v1beta1.PlatformStatus{
+				PlatformName:        platform,
+				PlatformStatus:      result.status,
+				InstalledCSV:        result.details.InstalledCSV,
+				PendingInstallPlans: result.details.PendingInstallPlans,
+			}
+			if previous := FindStatusPlatform(nextStatus.PlatformsStatus, platform); previous != nil && !result.reconciled {
+				nextPlatformStatus.InstalledCSV = previous.InstalledCSV
+			}
+			if result.err != nil {
+				nextPlatformStatus.LastMessage = result.err.Error()
+				nextPlatformStatus.PlatformStatus = v1beta1.ResultFailed
+				errs = append(errs, fmt.Errorf("error installing platform %s: %w", platform, result.err))
+			} else {
+				nextPlatformStatus.LastMessage = result.message
+			}
+			setPlatformStatusTimes(FindStatusPlatform(nextStatus.PlatformsStatus, platform), &nextPlatformStatus, now)
+			setStatusPlatform(&nextStatus.PlatformsStatus, nextPlatformStatus)
+			results[platform] = nextPlatformStatus.PlatformStatus
+			if nextPlatformStatus.PlatformStatus == v1beta1.ResultSkipped {
+				logger.V(1).Info("DBaaS platform skipped", "platform", platform, "reason", nextPlatformStatus.LastMessage)
+			} else if nextPlatformStatus.PlatformStatus != v1beta1.ResultSuccess {
+				logger.Info("DBaaS platform install in progress", "working platform", platform, "status", nextPlatformStatus.PlatformStatus)
+				finished = false
+			}
+		}
+	}
+	return finished, utilerrors.NewAggregate(errs)
+}
+
+// setPlatformStatusTimes sets the start and completion times of the current installation of a platform
+func setPlatformStatusTimes(previous *v1beta1.PlatformStatus, next *v1beta1.PlatformStatus, now metav1.Time) {
+	if previous != nil {
+		next.StartTime = previous.StartTime
+		next.CompletionTime = previous.CompletionTime
+	}
+	switch next.PlatformStatus {
+	case v1beta1.ResultWaiting, v1beta1.ResultSkipped:
+		next.StartTime = nil
+		next.CompletionTime = nil
+	case v1beta1.ResultSuccess:
+		if next.StartTime == nil {
+			next.StartTime = &now
+		}
+		if next.CompletionTime == nil {
+			next.CompletionTime = &now
+		}
+	default:
+		// a completed platform that is not successful anymore is being installed again
+		if next.StartTime == nil || next.CompletionTime != nil {
+			next.StartTime = &now
+			next.CompletionTime = nil
+		}
+	}
+}
+
+// cleanupDisabledPlatforms removes the installed platforms that are no longer enabled, and their status
+func (r *DBaaSPlatformReconciler) cleanupDisabledPlatforms(ctx context.Context, cr *v1beta1.DBaaSPlatform,
+	platforms map[v1beta1.PlatformName]v1beta1.PlatformConfig, capabilities reconcilers.Capabilities, nextStatus *v1beta1.DBaaSPlatformStatus) (bool, error) {
+	logger := log.FromContext(ctx)
+	finished := true
+	for _, platformStatus := range cr.Status.PlatformsStatus {
+		platform := platformStatus.PlatformName
+		if _, enabled := platforms[platform]; enabled {
+			continue
+		}
+		nextPlatformStatus := v1beta1.PlatformStatus{PlatformName: platform}
+		platformConfig := reconcilers.GetRemovedPlatformConfig(platform, cr.Spec.Platforms)
+		reconciler := r.getReconcilerForPlatform(platformConfig, capabilities)
+		if reconciler == nil || platformStatus.PlatformStatus == v1beta1.ResultSkipped || len(reconcilers.GetSkipReason(platformConfig, capabilities)) > 0 {
+			removeStatusPlatform(&nextStatus.PlatformsStatus, platform)
+			continue
+		}
+		status, err := reconciler.Cleanup(ctx, cr)
+		if err != nil {
+			nextPlatformStatus.PlatformStatus = v1beta1.ResultFailed
+			nextPlatformStatus.LastMessage = err.Error()
+			setStatusPlatform(&nextStatus.PlatformsStatus, nextPlatformStatus)
+			return false, err
+		}
+		if status != v1beta1.ResultSuccess {
+			logger.Info("DBaaS platform cleanup in progress", "disabled platform", platform)
+			nextPlatformStatus.PlatformStatus = status
+			setStatusPlatform(&nextStatus.PlatformsStatus, nextPlatformStatus)
+			finished = false
+			continue
+		}
+		logger.Info("DBaaS platform removed", "disabled platform", platform)
+		removeStatusPlatform(&nextStatus.PlatformsStatus, platform)
+	}
+	return finished, nil
+}
+
+func (r *DBaaSPlatformReconciler) getReconcilerForPlatform(platformConfig v1beta1.PlatformConfig, capabilities reconcilers.Capabilities) reconcilers.PlatformReconciler {
 	switch platformConfig.Type {
 	case v1beta1.TypeOperator:
+		if reconcilers.UseManifests(platformConfig, capabilities) {
+			return manifestsinstallation.NewReconciler(r.Client, r.Log, platformConfig)
+		}
 		return providersinstallation.NewReconciler(r.Client, r.Scheme, r.Log, platformConfig)
 	case v1beta1.TypeConsolePlugin:
 		return consoleplugin.NewReconciler(r.Client, r.Scheme, r.Log, platformConfig)
@@ -346,6 +551,20 @@ func setStatusPlatform(PlatformsStatus *[]v1beta1.PlatformStatus, newPlatformSta
 
 	existingPlatformStatus.PlatformStatus = newPlatformStatus.PlatformStatus
 	existingPlatformStatus.LastMessage = newPlatformStatus.LastMessage
+	existingPlatformStatus.StartTime = newPlatformStatus.StartTime
+	existingPlatformStatus.CompletionTime = newPlatformStatus.CompletionTime
+	existingPlatformStatus.InstalledCSV = newPlatformStatus.InstalledCSV
+	existingPlatformStatus.PendingInstallPlans = newPlatformStatus.PendingInstallPlans
+}
+
+// removeStatusPlatform removes the platformName from platforms status.
+func removeStatusPlatform(platformsStatus *[]v1beta1.PlatformStatus, platformName v1beta1.PlatformName) {
+	for i := range *platformsStatus {
+		if (*platformsStatus)[i].PlatformName == platformName {
+			*platformsStatus = append((*platformsStatus)[:i], (*platformsStatus)[i+1:]...)
+			return
+		}
+	}
 }
 
 // FindStatusPlatform finds the platformName in platforms status.
@@ -414,6 +633,10 @@ func (r *DBaaSPlatformReconciler) checkConversionWebhookHealth(ctx context.Conte
 func (r *DBaaSPlatformReconciler) fixConversionWebhooks(ctx context.Context) error {
 	owner, err := reconcilers.GetDBaaSOperatorCSV(ctx, r.InstallNamespace, r.operatorNameVersion, r.Client)
 	if err != nil {
+		if apimeta.IsNoMatchError(err) {
+			// OLM is not installed
+			return nil
+		}
 		return err
 	}
 	if owner.Status.Phase == v1alpha1.CSVPhaseSucceeded || owner.Status.Phase == v1alpha1.CSVPhaseInstalling {
@@ -497,7 +720,7 @@ func (r *DBaaSPlatformReconciler) fixRDSControllerUpgrade(ctx context.Context) e
 		},
 	}
 	if err := r.Client.Get(ctx, k8sclient.ObjectKeyFromObject(csv), csv); err != nil {
-		if apierrors.IsNotFound(err) {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
 			return nil
 		}
 		return err
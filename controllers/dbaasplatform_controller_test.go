@@ -1,6 +1,10 @@
 package controllers
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -9,9 +13,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	dbaasv1beta1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/util"
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 )
 
@@ -47,6 +55,14 @@ var _ = Describe("DBaaSPlatform controller", func() {
 			Expect(cr.Status.Conditions).NotTo(BeEmpty())
 			Expect(cr.Status.Conditions[0].Type).To(Equal(dbaasv1beta1.DBaaSPlatformReadyType))
 		})
+		It("should add the platform finalizer", func() {
+			Eventually(func() bool {
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(cr), cr); err != nil {
+					return false
+				}
+				return controllerutil.ContainsFinalizer(cr, dbaasv1beta1.DBaaSPlatformFinalizer)
+			}, timeout).Should(BeTrue())
+		})
 	})
 
 	Describe("install dummy secret and configmap for rds-controller upgrade", func() {
@@ -194,3 +210,119 @@ var _ = Describe("DBaaSPlatform controller", func() {
 		})
 	})
 })
+
+var _ = Describe("DBaaSPlatform installation times", func() {
+	startTime := metav1.NewTime(metav1.Now().Add(-time.Hour))
+	now := metav1.Now()
+
+	It("should record the start and completion times of an installation", func() {
+		next := &dbaasv1beta1.PlatformStatus{PlatformStatus: dbaasv1beta1.ResultInProgress}
+		setPlatformStatusTimes(nil, next, startTime)
+		Expect(next.StartTime).Should(Equal(&startTime))
+		Expect(next.CompletionTime).Should(BeNil())
+
+		previous := next.DeepCopy()
+		next = &dbaasv1beta1.PlatformStatus{PlatformStatus: dbaasv1beta1.ResultSuccess}
+		setPlatformStatusTimes(previous, next, now)
+		Expect(next.StartTime).Should(Equal(&startTime))
+		Expect(next.CompletionTime).Should(Equal(&now))
+	})
+
+	It("should restart the times when a completed platform is installed again", func() {
+		previous := &dbaasv1beta1.PlatformStatus{
+			PlatformStatus: dbaasv1beta1.ResultSuccess,
+			StartTime:      &startTime,
+			CompletionTime: &startTime,
+		}
+		next := &dbaasv1beta1.PlatformStatus{PlatformStatus: dbaasv1beta1.ResultFailed}
+		setPlatformStatusTimes(previous, next, now)
+		Expect(next.StartTime).Should(Equal(&now))
+		Expect(next.CompletionTime).Should(BeNil())
+	})
+
+	It("should not set the times of a platform waiting for its dependencies", func() {
+		next := &dbaasv1beta1.PlatformStatus{PlatformStatus: dbaasv1beta1.ResultWaiting}
+		setPlatformStatusTimes(nil, next, now)
+		Expect(next.StartTime).Should(BeNil())
+		Expect(next.CompletionTime).Should(BeNil())
+	})
+})
+
+var _ = Describe("DBaaSPlatform images check", func() {
+	digestImage := "registry.example.com/dbaas/catalog@sha256:0123456789abcdef"
+	mirrors := []util.ImageMirror{
+		{
+			Source:  "registry.example.com/dbaas",
+			Mirrors: []string{"mirror.local:5000/dbaas"},
+		},
+	}
+	var checkRegistryReachable func(context.Context, string) error
+
+	BeforeEach(func() {
+		checkRegistryReachable = util.CheckRegistryReachable
+		util.CheckRegistryReachable = func(_ context.Context, registry string) error {
+			if registry == "mirror.local:5000" {
+				return nil
+			}
+			return fmt.Errorf("no route to host")
+		}
+	})
+	AfterEach(func() {
+		util.CheckRegistryReachable = checkRegistryReachable
+	})
+
+	It("should pull the images pinned by digest from the mirrors", func() {
+		image := &dbaasv1beta1.ImageStatus{Image: digestImage, DigestPinned: true}
+		checkImage(ctx, image, mirrors, map[string]error{})
+		Expect(image.Reachable).Should(BeTrue())
+		Expect(image.Message).Should(Equal("pulled from mirror.local:5000/dbaas/catalog@sha256:0123456789abcdef"))
+	})
+
+	It("should report the unreachable images", func() {
+		image := &dbaasv1beta1.ImageStatus{Image: "registry.example.com/dbaas/catalog:v1.0.0"}
+		checkImage(ctx, image, mirrors, map[string]error{})
+		Expect(image.Reachable).Should(BeFalse())
+		Expect(image.Message).Should(Equal("unreachable, registry.example.com: no route to host; image mirrors only apply to images pinned by digest"))
+
+		unreachable := unreachablePlatforms([]dbaasv1beta1.ImageStatus{
+			{PlatformName: dbaasv1beta1.CrunchyBridgeInstallation, Image: image.Image, Message: image.Message},
+			{PlatformName: dbaasv1beta1.RDSProviderInstallation, Image: digestImage, Reachable: true},
+		})
+		Expect(unreachable).Should(HaveLen(1))
+		Expect(unreachable).Should(HaveKey(dbaasv1beta1.CrunchyBridgeInstallation))
+	})
+
+	It("should only check the images again when they change or the last check is too old", func() {
+		now := time.Now()
+		images := []dbaasv1beta1.ImageStatus{{PlatformName: dbaasv1beta1.CrunchyBridgeInstallation, Image: digestImage}}
+		status := &dbaasv1beta1.DBaaSPlatformStatus{
+			Images:          images,
+			ImagesCheckTime: &metav1.Time{Time: now.Add(-time.Minute)},
+		}
+		Expect(imagesCheckDue(status, images, now)).Should(BeFalse())
+		Expect(imagesCheckDue(status, []dbaasv1beta1.ImageStatus{{PlatformName: dbaasv1beta1.CrunchyBridgeInstallation, Image: "other"}}, now)).Should(BeTrue())
+		Expect(imagesCheckDue(status, images, now.Add(imagesCheckInterval))).Should(BeTrue())
+	})
+})
+
+var _ = Describe("DBaaSPlatform on plain Kubernetes", func() {
+	It("should skip the platforms requiring missing capabilities", func() {
+		r := &DBaaSPlatformReconciler{DBaaSReconciler: dRec, Log: ctrl.Log.WithName("controllers").WithName("DBaaSPlatform")}
+		cr := &dbaasv1beta1.DBaaSPlatform{ObjectMeta: metav1.ObjectMeta{Name: "dbaas-platform", Namespace: testNamespace}}
+		platforms, err := reconcilers.GetInstallationPlatforms(nil)
+		Expect(err).NotTo(HaveOccurred())
+		levels, err := reconcilers.GetInstallationOrder(platforms)
+		Expect(err).NotTo(HaveOccurred())
+
+		status := &dbaasv1beta1.DBaaSPlatformStatus{}
+		finished, err := r.installPlatforms(ctx, cr, platforms, levels, reconcilers.Capabilities{}, nil, status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finished).Should(BeTrue())
+		Expect(status.PlatformsStatus).Should(HaveLen(len(platforms)))
+		for _, platformStatus := range status.PlatformsStatus {
+			Expect(platformStatus.PlatformStatus).Should(Equal(dbaasv1beta1.ResultSkipped))
+			Expect(platformStatus.LastMessage).Should(HavePrefix("missing cluster capabilities"))
+			Expect(platformStatus.StartTime).Should(BeNil())
+		}
+	})
+})
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/util"
+)
+
+// imagesCheckInterval is the minimum interval between two checks of the same platform images
+const imagesCheckInterval = 10 * time.Minute
+
+//+kubebuilder:rbac:groups=operator.openshift.io,resources=imagecontentsourcepolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=config.openshift.io,resources=imagedigestmirrorsets,verbs=get;list;watch
+
+// verifyPlatformImages checks that the platform images are reachable when verifyImages is enabled,
+// and returns the platforms with unreachable images
+func (r *DBaaSPlatformReconciler) verifyPlatformImages(ctx context.Context, cr *v1beta1.DBaaSPlatform,
+	platforms map[v1beta1.PlatformName]v1beta1.PlatformConfig, nextStatus *v1beta1.DBaaSPlatformStatus) map[v1beta1.PlatformName]string {
+	if !cr.Spec.VerifyImages {
+		nextStatus.Images = nil
+		nextStatus.ImagesCheckTime = nil
+		apimeta.RemoveStatusCondition(&nextStatus.Conditions, v1beta1.DBaaSPlatformImagesReadyType)
+		return nil
+	}
+
+	images := getPlatformImages(platforms)
+	if !imagesCheckDue(nextStatus, images, time.Now()) {
+		return unreachablePlatforms(nextStatus.Images)
+	}
+
+	logger := log.FromContext(ctx)
+	mirrors, err := util.GetImageMirrors(ctx, r.Client)
+	if err != nil {
+		logger.Error(err, "Error reading the image mirrors, checking the images without mirrors")
+	}
+	registries := map[string]error{}
+	for i := range images {
+		checkImage(ctx, &images[i], mirrors, registries)
+	}
+
+	now := metav1.Now()
+	nextStatus.Images = images
+	nextStatus.ImagesCheckTime = &now
+	unreachable := unreachablePlatforms(images)
+	if len(unreachable) > 0 {
+		var names []string
+		for platform := range unreachable {
+			names = append(names, string(platform))
+		}
+		sort.Strings(names)
+		setStatusCondition(&nextStatus.Conditions, v1beta1.DBaaSPlatformImagesReadyType, metav1.ConditionFalse, v1beta1.ImagesUnreachable,
+			fmt.Sprintf("Images of platforms %s are unreachable", strings.Join(names, ", ")))
+	} else {
+		setStatusCondition(&nextStatus.Conditions, v1beta1.DBaaSPlatformImagesReadyType, metav1.ConditionTrue, v1beta1.Ready, "All platform images are reachable")
+	}
+	logger.Info("DBaaS platform images checked", "unreachable platforms", len(unreachable))
+	return unreachable
+}
+
+// getPlatformImages returns the images of the platforms, sorted by platform
+func getPlatformImages(platforms map[v1beta1.PlatformName]v1beta1.PlatformConfig) []v1beta1.ImageStatus {
+	var images []v1beta1.ImageStatus
+	for platform, config := range platforms {
+		if len(config.Image) == 0 || (config.Type != v1beta1.TypeOperator && config.Type != v1beta1.TypeConsolePlugin) {
+			continue
+		}
+		images = append(images, v1beta1.ImageStatus{
+			PlatformName: platform,
+			Image:        config.Image,
+			DigestPinned: util.IsDigestPinned(config.Image),
+		})
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].PlatformName < images[j].PlatformName
+	})
+	return images
+}
+
+// imagesCheckDue returns true if the platform images changed, or if the last check is too old
+func imagesCheckDue(status *v1beta1.DBaaSPlatformStatus, images []v1beta1.ImageStatus, now time.Time) bool {
+	if status.ImagesCheckTime == nil || now.Sub(status.ImagesCheckTime.Time) >= imagesCheckInterval {
+		return true
+	}
+	if len(status.Images) != len(images) {
+		return true
+	}
+	for i := range images {
+		if status.Images[i].PlatformName != images[i].PlatformName || status.Images[i].Image != images[i].Image {
+			return true
+		}
+	}
+	return false
+}
+
+// checkImage checks if an image is reachable, directly or through a mirror, the registries are only checked once
+func checkImage(ctx context.Context, image *v1beta1.ImageStatus, mirrors []util.ImageMirror, registries map[string]error) {
+	var errs []string
+	for _, source := range util.GetImagePullSources(image.Image, mirrors) {
+		registry := util.GetImageRegistry(source)
+		err, checked := registries[registry]
+		if !checked {
+			err = util.CheckRegistryReachable(ctx, registry)
+			registries[registry] = err
+		}
+		if err == nil {
+			image.Reachable = true
+			image.Message = fmt.Sprintf("pulled from %s", source)
+			return
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", registry, err))
+	}
+	image.Reachable = false
+	image.Message = fmt.Sprintf("unreachable, %s", strings.Join(errs, "; "))
+	if !image.DigestPinned && len(mirrors) > 0 {
+		image.Message += "; image mirrors only apply to images pinned by digest"
+	}
+}
+
+// unreachablePlatforms returns the platforms with unreachable images, and the reason
+func unreachablePlatforms(images []v1beta1.ImageStatus) map[v1beta1.PlatformName]string {
+	unreachable := map[v1beta1.PlatformName]string{}
+	for _, image := range images {
+		if !image.Reachable {
+			unreachable[image.PlatformName] = fmt.Sprintf("image %s is %s", image.Image, image.Message)
+		}
+	}
+	return unreachable
+}
@@ -27,8 +27,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // DBaaSPolicyReconciler reconciles a DBaaSPolicy object
@@ -60,7 +64,7 @@ func (r *DBaaSPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		metricLabelErrCdValue = metrics.LabelErrorCdValueUnableToListPolicies
 		return ctrl.Result{}, err
 	}
-	activePolicy := getActivePolicy(policyList)
+	activePolicy := v1beta1.GetActivePolicy(policyList.Items)
 
 	var policy v1beta1.DBaaSPolicy
 	if err := r.Get(ctx, req.NamespacedName, &policy); err != nil {
@@ -138,6 +142,26 @@ func (r *DBaaSPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
+	effectivePolicy, err := r.getEffectivePolicy(ctx, nil, &policy)
+	if err != nil {
+		logger.Error(err, "Error listing the DBaaS Cluster Policies for the effective policy")
+		metricLabelErrCdValue = metrics.LabelErrorCdValueUnableToListPolicies
+		return ctrl.Result{}, err
+	}
+	policy.Status.EffectivePolicy = effectivePolicy
+
+	// the active policy reports the usage of its quotas
+	policy.Status.Usage = nil
+	if cond.Status == metav1.ConditionTrue {
+		var instanceList v1beta1.DBaaSInstanceList
+		if err := r.List(ctx, &instanceList, client.MatchingFields{v1beta1.InstanceInventoryNamespaceIndex: policy.Namespace}); err != nil {
+			logger.Error(err, "Error listing the DBaaS Instances for the quota usage")
+			metricLabelErrCdValue = metrics.LabelErrorCdValueErrorListingPolicyInstances
+			return ctrl.Result{}, err
+		}
+		policy.Status.Usage = v1beta1.GetPolicyUsage(policy.Namespace, instanceList.Items)
+	}
+
 	return r.updateStatusCondition(ctx, policy, cond)
 }
 
@@ -146,9 +170,43 @@ func (r *DBaaSPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.DBaaSPolicy{}).
 		Owns(&v1.ResourceQuota{}).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInstance{}}, handler.EnqueueRequestsFromMapFunc(r.instancePolicyRequests)).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSClusterPolicy{}}, handler.EnqueueRequestsFromMapFunc(r.clusterPolicyRequests)).
 		Complete(r)
 }
 
+// clusterPolicyRequests enqueues all the policies, to update their effective policy
+func (r *DBaaSPolicyReconciler) clusterPolicyRequests(o client.Object) []reconcile.Request {
+	var policyList v1beta1.DBaaSPolicyList
+	if err := r.List(context.Background(), &policyList); err != nil {
+		ctrl.Log.Error(err, "Error listing DBaaS Policies for the DBaaS Cluster Policy change", "clusterPolicy", o.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range policyList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policyList.Items[i])})
+	}
+	return requests
+}
+
+// instancePolicyRequests enqueues the policies of the inventory namespace of an instance, to update the quota usage
+func (r *DBaaSPolicyReconciler) instancePolicyRequests(o client.Object) []reconcile.Request {
+	instance, ok := o.(*v1beta1.DBaaSInstance)
+	if !ok {
+		return nil
+	}
+	policyList, err := r.policyListByNS(context.Background(), v1beta1.GetInstanceInventoryNamespace(instance))
+	if err != nil {
+		ctrl.Log.Error(err, "Error listing DBaaS Policies for the DBaaS Instance change", "instance", instance.Name)
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range policyList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policyList.Items[i])})
+	}
+	return requests
+}
+
 func (r *DBaaSPolicyReconciler) updateStatusCondition(ctx context.Context, policy v1beta1.DBaaSPolicy, cond *metav1.Condition) (ctrl.Result, error) {
 	logger := ctrl.LoggerFrom(ctx)
 	apimeta.SetStatusCondition(&policy.Status.Conditions, *cond)
@@ -163,16 +221,6 @@ func (r *DBaaSPolicyReconciler) updateStatusCondition(ctx context.Context, polic
 	return ctrl.Result{}, nil
 }
 
-// get active policy, return nil if none exists
-func getActivePolicy(policyList v1beta1.DBaaSPolicyList) *v1beta1.DBaaSPolicy {
-	for i := range policyList.Items {
-		if apimeta.IsStatusConditionTrue(policyList.Items[i].Status.Conditions, v1beta1.DBaaSPolicyReadyType) {
-			return &policyList.Items[i]
-		}
-	}
-	return nil
-}
-
 // Delete implements a handler for the Delete event.
 func (r *DBaaSPolicyReconciler) Delete(e event.DeleteEvent) error {
 	execution := metrics.PlatformInstallStart()
@@ -44,5 +44,60 @@ var _ = Describe("DBaaSPolicy controller", func() {
 				Expect(getPolicy.Status.Conditions[0].Message).Should(Equal(v1beta1.MsgPolicyNotReady + " - " + defaultPolicy.GetName()))
 			})
 		})
+
+		Context("after creating a DBaaSClusterPolicy", func() {
+			isTrue := true
+			clusterPolicy := &v1beta1.DBaaSClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster-policy",
+				},
+				Spec: v1beta1.DBaaSClusterPolicySpec{
+					RequireApproval: &isTrue,
+				},
+			}
+			BeforeEach(assertResourceCreationIfNotExists(clusterPolicy))
+			AfterEach(assertResourceDeletion(clusterPolicy))
+
+			It("should report the cluster policy ceilings in the effective policy", func() {
+				Eventually(func() bool {
+					getPolicy := v1beta1.DBaaSPolicy{}
+					if err := dRec.Get(ctx, client.ObjectKeyFromObject(&defaultPolicy), &getPolicy); err != nil || getPolicy.Status.EffectivePolicy == nil {
+						return false
+					}
+					return getPolicy.Status.EffectivePolicy.RequireApproval != nil && *getPolicy.Status.EffectivePolicy.RequireApproval
+				}, timeout).Should(BeTrue())
+			})
+		})
+
+		Context("after creating a DBaaSInstance", func() {
+			instance := &v1beta1.DBaaSInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-quota-instance",
+					Namespace: testNamespace,
+				},
+				Spec: v1beta1.DBaaSInstanceSpec{
+					InventoryRef: v1beta1.NamespacedName{
+						Name:      "test-quota-inventory",
+						Namespace: testNamespace,
+					},
+					ProvisioningParameters: map[v1beta1.ProvisioningParameterType]string{
+						v1beta1.ProvisioningName:       "test-quota-instance",
+						v1beta1.ProvisioningSpendLimit: "5",
+					},
+				},
+			}
+			BeforeEach(assertResourceCreationIfNotExists(instance))
+			AfterEach(assertResourceDeletion(instance))
+
+			It("should report the instance in the quota usage of the active policy", func() {
+				Eventually(func() int32 {
+					getPolicy := v1beta1.DBaaSPolicy{}
+					if err := dRec.Get(ctx, client.ObjectKeyFromObject(&defaultPolicy), &getPolicy); err != nil || getPolicy.Status.Usage == nil {
+						return 0
+					}
+					return getPolicy.Status.Usage.InstancesPerInventory["test-quota-inventory"]
+				}, timeout).Should(Equal(int32(1)))
+			})
+		})
 	})
 })
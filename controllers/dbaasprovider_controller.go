@@ -19,12 +19,19 @@ package controllers
 import (
 	"context"
 
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/metrics"
@@ -36,11 +43,26 @@ type DBaaSProviderReconciler struct {
 	ConnectionCtrl controller.Controller
 	InventoryCtrl  controller.Controller
 	InstanceCtrl   controller.Controller
+	BackupCtrl     controller.Controller
+	RestoreCtrl    controller.Controller
+
+	providerWatches *providerWatches
+	// apiReader reads the provider operator deployments, which are only cached as metadata
+	apiReader client.Reader
+}
+
+// providerKind is a provider kind watched by a DBaaS controller
+type providerKind struct {
+	ctrl    controller.Controller
+	owner   runtime.Object
+	gvk     schema.GroupVersionKind
+	errorCd string
 }
 
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -58,6 +80,7 @@ func (r *DBaaSProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		if errors.IsNotFound(err) {
 			// CR deleted since request queued, child objects getting GC'd, no requeue
 			logger.V(1).Info("DBaaS Provider resource not found, has been deleted")
+			r.providerWatches.release(req.Name)
 			metricLabelErrCdValue = metrics.LabelErrorCdValueResourceNotFound
 			return ctrl.Result{}, nil
 		}
@@ -72,40 +95,60 @@ func (r *DBaaSProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		event = metrics.LabelEventValueCreate
 	}
 
-	groupVersion := provider.GetDBaaSAPIGroupVersion()
+	defer func() {
+		metrics.SetProviderMetrics(provider, provider.Name, execution, event, metricLabelErrCdValue)
+	}()
 
-	if err := r.watchDBaaSProviderObject(r.InventoryCtrl, &v1beta1.DBaaSInventory{}, provider.Spec.InventoryKind, &groupVersion); err != nil {
-		logger.Error(err, "Error watching Provider Inventory CR", "Kind", provider.Spec.InventoryKind)
-		metricLabelErrCdValue = metrics.LabelErrorCdValueErrorWatchingInventoryCR
+	if err := r.reconcileProviderStatus(ctx, &provider); err != nil {
+		logger.Error(err, "Error updating the DBaaS Provider status")
 		return ctrl.Result{}, err
 	}
-	logger.Info("Watching Provider Inventory CR", "Kind", provider.Spec.InventoryKind)
 
-	if err := r.watchDBaaSProviderObject(r.ConnectionCtrl, &v1beta1.DBaaSConnection{}, provider.Spec.ConnectionKind, &groupVersion); err != nil {
-		logger.Error(err, "Error watching Provider Connection CR", "Kind", provider.Spec.ConnectionKind)
-		metricLabelErrCdValue = metrics.LabelErrorCdValueErrorWatchingConnectionCR
+	// the kinds whose CRDs are missing are watched once the provider is checked again
+	missing, err := r.getMissingProviderKinds(&provider)
+	if err != nil {
+		logger.Error(err, "Error checking the DBaaS Provider kinds")
 		return ctrl.Result{}, err
 	}
-	logger.Info("Watching Provider Connection CR", "Kind", provider.Spec.ConnectionKind)
-
-	if err := r.watchDBaaSProviderObject(r.InstanceCtrl, &v1beta1.DBaaSInstance{}, provider.Spec.InstanceKind, &groupVersion); err != nil {
-		metricLabelErrCdValue = metrics.LabelErrorCdValueErrorWatchingInstanceCR
-		logger.Error(err, "Error watching Provider Instance CR", "Kind", provider.Spec.InstanceKind)
-		return ctrl.Result{}, err
+	missingKinds := map[string]bool{}
+	for _, kind := range missing {
+		missingKinds[kind] = true
 	}
-	logger.Info("Watching Provider Instance CR", "Kind", provider.Spec.InstanceKind)
 
-	defer func() {
-		metrics.SetProviderMetrics(provider, provider.Name, execution, event, metricLabelErrCdValue)
-	}()
+	var watched []providerWatchKey
+	for _, providerKind := range r.getProviderKinds(&provider) {
+		if missingKinds[providerKind.gvk.Kind] {
+			continue
+		}
+		started, err := r.providerWatches.watch(provider.Name, providerKind.ctrl, providerKind.owner, providerKind.gvk)
+		if err != nil {
+			logger.Error(err, "Error watching Provider CR", "Kind", providerKind.gvk.Kind)
+			metricLabelErrCdValue = providerKind.errorCd
+			return ctrl.Result{}, err
+		}
+		if started {
+			logger.Info("Watching Provider CR", "Kind", providerKind.gvk.Kind)
+		}
+		watched = append(watched, providerWatchKey{ctrl: providerKind.ctrl, gvk: providerKind.gvk})
+	}
+	// the kinds removed from the provider are not watched anymore
+	r.providerWatches.release(provider.Name, watched...)
 
+	if !apimeta.IsStatusConditionTrue(provider.Status.Conditions, v1beta1.DBaaSProviderReadyType) {
+		// the provider CRDs and operator deployment are checked again later, the CRDs are not watched
+		return ctrl.Result{RequeueAfter: providerRecheckDelay}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.providerWatches = newProviderWatches(mgr.GetConfig(), mgr.GetScheme(), mgr.GetRESTMapper())
+	r.apiReader = mgr.GetAPIReader()
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.DBaaSProvider{}, builder.WithPredicates(filterEventPredicate)).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInventory{}}, handler.EnqueueRequestsFromMapFunc(r.inventoryProviderRequests)).
+		Watches(&source.Kind{Type: &appsv1.Deployment{}}, handler.EnqueueRequestsFromMapFunc(r.operatorProviderRequests), builder.OnlyMetadata).
 		Complete(r)
 }
 
@@ -117,13 +160,32 @@ var filterEventPredicate = predicate.Funcs{
 		return updateEvent.ObjectNew.GetGeneration() != updateEvent.ObjectOld.GetGeneration()
 	},
 	DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
-		return false
+		// the watches of the deleted provider are released
+		return true
 	},
 	GenericFunc: func(genericEvent event.GenericEvent) bool {
 		return false
 	},
 }
 
+// getProviderKinds returns the provider kinds watched by the DBaaS controllers, the kinds that are not set are ignored
+func (r *DBaaSProviderReconciler) getProviderKinds(provider *v1beta1.DBaaSProvider) []providerKind {
+	groupVersion := provider.GetDBaaSAPIGroupVersion()
+	var kinds []providerKind
+	for _, k := range []providerKind{
+		{ctrl: r.InventoryCtrl, owner: &v1beta1.DBaaSInventory{}, gvk: groupVersion.WithKind(provider.Spec.InventoryKind), errorCd: metrics.LabelErrorCdValueErrorWatchingInventoryCR},
+		{ctrl: r.ConnectionCtrl, owner: &v1beta1.DBaaSConnection{}, gvk: groupVersion.WithKind(provider.Spec.ConnectionKind), errorCd: metrics.LabelErrorCdValueErrorWatchingConnectionCR},
+		{ctrl: r.InstanceCtrl, owner: &v1beta1.DBaaSInstance{}, gvk: groupVersion.WithKind(provider.Spec.InstanceKind), errorCd: metrics.LabelErrorCdValueErrorWatchingInstanceCR},
+		{ctrl: r.BackupCtrl, owner: &v1beta1.DBaaSBackup{}, gvk: groupVersion.WithKind(provider.Spec.BackupKind)},
+		{ctrl: r.RestoreCtrl, owner: &v1beta1.DBaaSRestore{}, gvk: groupVersion.WithKind(provider.Spec.RestoreKind)},
+	} {
+		if len(k.gvk.Kind) > 0 {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
 // Delete implements a handler for the Delete event.
 func (r *DBaaSProviderReconciler) Delete(e event.DeleteEvent) error {
 	execution := metrics.PlatformInstallStart()
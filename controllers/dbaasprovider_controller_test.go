@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -134,6 +135,9 @@ var _ = Describe("DBaaSProvider controller", func() {
 			}, timeout).Should(Equal(updatedProvider.Spec))
 
 			assertWatched(uiSrc, iOwner, ucSrc, cOwner, uinSrc, inOwner)
+			Eventually(func() bool {
+				return pRec.providerWatches.isWatched(iCtrl, iSrc.GroupVersionKind())
+			}, timeout).Should(BeFalse())
 		})
 	})
 
@@ -194,10 +198,94 @@ var _ = Describe("DBaaSProvider controller", func() {
 
 			assertResourceDeletion(provider)()
 			assertNotWatched(iSrc, iOwner, cSrc, cOwner, inSrc, inOwner)
+			Eventually(func() bool {
+				return pRec.providerWatches.isWatched(iCtrl, iSrc.GroupVersionKind()) ||
+					pRec.providerWatches.isWatched(cCtrl, cSrc.GroupVersionKind()) ||
+					pRec.providerWatches.isWatched(inCtrl, inSrc.GroupVersionKind())
+			}, timeout).Should(BeFalse())
 		})
 	})
 })
 
+var _ = Describe("DBaaSProvider status", func() {
+	newProvider := func(name, groupVersion, inventoryKind string) *v1beta1.DBaaSProvider {
+		return &v1beta1.DBaaSProvider{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: v1beta1.DBaaSProviderSpec{
+				Provider: v1beta1.DatabaseProviderInfo{
+					Name: name,
+				},
+				GroupVersion:     groupVersion,
+				InventoryKind:    inventoryKind,
+				ConnectionKind:   testConnectionKind,
+				InstanceKind:     testInstanceKind,
+				CredentialFields: []v1beta1.CredentialField{},
+			},
+		}
+	}
+	assertProviderReadyReason := func(provider *v1beta1.DBaaSProvider, reason string) {
+		Eventually(func() string {
+			if err := dRec.Get(ctx, client.ObjectKeyFromObject(provider), provider); err != nil {
+				return err.Error()
+			}
+			condition := apimeta.FindStatusCondition(provider.Status.Conditions, v1beta1.DBaaSProviderReadyType)
+			if condition == nil {
+				return ""
+			}
+			return condition.Reason
+		}, timeout).Should(Equal(reason))
+	}
+
+	Context("when the provider kinds are missing", func() {
+		provider := newProvider("test-missing-crds-provider", v1beta1.GroupVersion.String(), "MissingInventory")
+		BeforeEach(assertResourceCreation(provider))
+		AfterEach(assertResourceDeletion(provider))
+
+		It("should report the missing CRDs", func() {
+			assertProviderReadyReason(provider, v1beta1.DBaaSProviderCRDsMissing)
+			Expect(apimeta.FindStatusCondition(provider.Status.Conditions, v1beta1.DBaaSProviderReadyType).Message).Should(
+				Equal("The custom resource definitions of the provider kinds MissingInventory are missing at dbaas.redhat.com/v1beta1"))
+			Consistently(func() bool {
+				return pRec.providerWatches.isWatched(iCtrl, v1beta1.GroupVersion.WithKind("MissingInventory"))
+			}).Should(BeFalse())
+		})
+	})
+
+	Context("when the provider API version is not supported", func() {
+		provider := newProvider("test-unsupported-provider", "dbaas.redhat.com/v2", testInventoryKind)
+		BeforeEach(assertResourceCreation(provider))
+		AfterEach(assertResourceDeletion(provider))
+
+		It("should report the unsupported API version", func() {
+			assertProviderReadyReason(provider, v1beta1.DBaaSProviderUnsupportedAPI)
+		})
+	})
+
+	Context("when the provider operator is not running", func() {
+		provider := newProvider("test-operator-provider", v1beta1.GroupVersion.String(), testInventoryKind)
+		provider.Spec.OperatorDeploymentName = "test-provider-operator"
+		BeforeEach(assertResourceCreation(provider))
+		AfterEach(assertResourceDeletion(provider))
+
+		It("should report the operator is not ready", func() {
+			assertProviderReadyReason(provider, v1beta1.DBaaSProviderOperatorNotReady)
+			Expect(provider.Status.Inventories).Should(BeZero())
+		})
+	})
+
+	It("should only support the DBaaS API versions", func() {
+		provider := newProvider("test", "", testInventoryKind)
+		Expect(checkProviderAPIVersion(provider)).Should(BeEmpty())
+		provider.Spec.GroupVersion = "dbaas.redhat.com/v1alpha1"
+		Expect(checkProviderAPIVersion(provider)).Should(BeEmpty())
+		provider.Spec.GroupVersion = "example.com/v1beta1"
+		Expect(checkProviderAPIVersion(provider)).Should(Equal(
+			"Provider API group version example.com/v1beta1 is not supported, the supported versions are dbaas.redhat.com/v1alpha1, dbaas.redhat.com/v1beta1"))
+	})
+})
+
 func assertWatched(iSrc client.Object, iOwner runtime.Object,
 	cSrc client.Object, cOwner runtime.Object, inSrc client.Object, inOwner runtime.Object) {
 	Eventually(func() bool {
@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/providerapi"
+)
+
+// providerRecheckDelay is the delay before checking again a provider that is not ready
+const providerRecheckDelay = time.Minute
+
+// reconcileProviderStatus checks the provider API version, kinds and operator, and counts the inventories using the provider
+func (r *DBaaSProviderReconciler) reconcileProviderStatus(ctx context.Context, provider *v1beta1.DBaaSProvider) error {
+	status := provider.Status.DeepCopy()
+	var err error
+	if status.Inventories, status.ReadyInventories, err = r.countProviderInventories(ctx, provider); err != nil {
+		return err
+	}
+
+	if message := checkProviderAPIVersion(provider); len(message) > 0 {
+		setStatusCondition(&status.Conditions, v1beta1.DBaaSProviderReadyType, metav1.ConditionFalse, v1beta1.DBaaSProviderUnsupportedAPI, message)
+	} else if missing, err := r.getMissingProviderKinds(provider); err != nil {
+		return err
+	} else if len(missing) > 0 {
+		setStatusCondition(&status.Conditions, v1beta1.DBaaSProviderReadyType, metav1.ConditionFalse, v1beta1.DBaaSProviderCRDsMissing,
+			fmt.Sprintf("The custom resource definitions of the provider kinds %s are missing at %s", strings.Join(missing, ", "), provider.GetDBaaSAPIGroupVersion()))
+	} else if message, err := r.checkProviderOperator(ctx, provider); err != nil {
+		return err
+	} else if len(message) > 0 {
+		setStatusCondition(&status.Conditions, v1beta1.DBaaSProviderReadyType, metav1.ConditionFalse, v1beta1.DBaaSProviderOperatorNotReady, message)
+	} else {
+		setStatusCondition(&status.Conditions, v1beta1.DBaaSProviderReadyType, metav1.ConditionTrue, v1beta1.Ready, "Provider is ready")
+	}
+
+	if !reflect.DeepEqual(&provider.Status, status) {
+		status.DeepCopyInto(&provider.Status)
+		return r.Client.Status().Update(ctx, provider)
+	}
+	return nil
+}
+
+// checkProviderAPIVersion returns why the DBaaS API version of a provider is not supported, or an empty string if it is supported
+func checkProviderAPIVersion(provider *v1beta1.DBaaSProvider) string {
+	if len(provider.Spec.GroupVersion) == 0 {
+		// v1alpha1 is used by default
+		return ""
+	}
+	groupVersion, err := schema.ParseGroupVersion(provider.Spec.GroupVersion)
+	if err != nil {
+		return fmt.Sprintf("Invalid provider API group version %s: %v", provider.Spec.GroupVersion, err)
+	}
+	supportedVersions := providerapi.SupportedVersions()
+	if groupVersion.Group == v1beta1.GroupVersion.Group {
+		for _, version := range supportedVersions {
+			if groupVersion.Version == version {
+				return ""
+			}
+		}
+	}
+	supported := make([]string, len(supportedVersions))
+	for i, version := range supportedVersions {
+		supported[i] = v1beta1.GroupVersion.Group + "/" + version
+	}
+	return fmt.Sprintf("Provider API group version %s is not supported, the supported versions are %s", provider.Spec.GroupVersion, strings.Join(supported, ", "))
+}
+
+// getMissingProviderKinds returns the provider kinds that the cluster does not serve
+func (r *DBaaSProviderReconciler) getMissingProviderKinds(provider *v1beta1.DBaaSProvider) ([]string, error) {
+	groupVersion := provider.GetDBaaSAPIGroupVersion()
+	var missing []string
+	for _, kind := range []string{provider.Spec.InventoryKind, provider.Spec.ConnectionKind, provider.Spec.InstanceKind, provider.Spec.BackupKind, provider.Spec.RestoreKind} {
+		if len(kind) == 0 {
+			continue
+		}
+		if _, err := r.Client.RESTMapper().RESTMapping(groupVersion.WithKind(kind).GroupKind(), groupVersion.Version); err != nil {
+			if apimeta.IsNoMatchError(err) {
+				missing = append(missing, kind)
+				continue
+			}
+			return nil, err
+		}
+	}
+	return missing, nil
+}
+
+// checkProviderOperator returns why the provider operator is not running, or an empty string if it is running or not set
+func (r *DBaaSProviderReconciler) checkProviderOperator(ctx context.Context, provider *v1beta1.DBaaSProvider) (string, error) {
+	if len(provider.Spec.OperatorDeploymentName) == 0 {
+		return "", nil
+	}
+	deployment := &appsv1.Deployment{}
+	if err := r.apiReader.Get(ctx, types.NamespacedName{Name: provider.Spec.OperatorDeploymentName, Namespace: r.InstallNamespace}, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("Provider operator deployment %s not found", provider.Spec.OperatorDeploymentName), nil
+		}
+		return "", err
+	}
+	if deployment.Status.ReadyReplicas == 0 {
+		return fmt.Sprintf("Provider operator deployment %s has no ready replica", deployment.Name), nil
+	}
+	return "", nil
+}
+
+// countProviderInventories returns the number of inventories using a provider, and the number of those that are ready
+func (r *DBaaSProviderReconciler) countProviderInventories(ctx context.Context, provider *v1beta1.DBaaSProvider) (int32, int32, error) {
+	var inventoryList v1beta1.DBaaSInventoryList
+	if err := r.List(ctx, &inventoryList, client.MatchingFields{v1beta1.InventoryProviderIndex: provider.Name}); err != nil {
+		return 0, 0, err
+	}
+	var inventories, ready int32
+	for i := range inventoryList.Items {
+		inventory := &inventoryList.Items[i]
+		inventories++
+		if apimeta.IsStatusConditionTrue(inventory.Status.Conditions, v1beta1.DBaaSInventoryReadyType) {
+			ready++
+		}
+	}
+	return inventories, ready, nil
+}
+
+// inventoryProviderRequests enqueues the provider of an inventory, to update the inventory counts
+func (r *DBaaSProviderReconciler) inventoryProviderRequests(o client.Object) []reconcile.Request {
+	inventory, ok := o.(*v1beta1.DBaaSInventory)
+	if !ok || len(inventory.Spec.ProviderRef.Name) == 0 {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: inventory.Spec.ProviderRef.Name}}}
+}
+
+// operatorProviderRequests enqueues the providers whose operator is a deployment in the namespace of the DBaaS Operator
+func (r *DBaaSProviderReconciler) operatorProviderRequests(o client.Object) []reconcile.Request {
+	if o.GetNamespace() != r.InstallNamespace {
+		return nil
+	}
+	var providerList v1beta1.DBaaSProviderList
+	if err := r.List(context.Background(), &providerList); err != nil {
+		ctrl.Log.Error(err, "Error listing DBaaS Providers for the operator deployment change", "deployment", o.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range providerList.Items {
+		if providerList.Items[i].Spec.OperatorDeploymentName == o.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: providerList.Items[i].Name}})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// providerWatchKey identifies the watch of a provider kind by a DBaaS controller
+type providerWatchKey struct {
+	ctrl controller.Controller
+	gvk  schema.GroupVersionKind
+}
+
+// providerWatch is the watch of a provider kind, shared by the providers declaring the kind
+type providerWatch struct {
+	cancel    context.CancelFunc
+	providers map[string]bool
+}
+
+// providerWatches tracks the watches of the provider kinds. A kind is watched once per DBaaS controller,
+// and the watch is stopped when no provider declares the kind anymore.
+type providerWatches struct {
+	config  *rest.Config
+	scheme  *runtime.Scheme
+	mapper  meta.RESTMapper
+	mutex   sync.Mutex
+	watches map[providerWatchKey]*providerWatch
+}
+
+func newProviderWatches(config *rest.Config, scheme *runtime.Scheme, mapper meta.RESTMapper) *providerWatches {
+	return &providerWatches{
+		config:  config,
+		scheme:  scheme,
+		mapper:  mapper,
+		watches: map[providerWatchKey]*providerWatch{},
+	}
+}
+
+// watch makes a DBaaS controller watch a provider kind for a provider, the watch is only started if the kind
+// is not watched yet by the controller. It returns true if the watch is started.
+func (w *providerWatches) watch(provider string, ctrl controller.Controller, owner runtime.Object, gvk schema.GroupVersionKind) (bool, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	key := providerWatchKey{ctrl: ctrl, gvk: gvk}
+	if pw, ok := w.watches[key]; ok {
+		pw.providers[provider] = true
+		return false, nil
+	}
+
+	// the provider objects are watched from a dedicated cache, so the informer can be stopped with the watch
+	providerCache, err := cache.New(w.config, cache.Options{Scheme: w.scheme, Mapper: w.mapper})
+	if err != nil {
+		return false, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	providerObject := &unstructured.Unstructured{}
+	providerObject.SetGroupVersionKind(gvk)
+	src := &providerKindSource{Kind: &source.Kind{Type: providerObject}, ctx: ctx}
+	if err := src.InjectCache(providerCache); err != nil {
+		cancel()
+		return false, err
+	}
+	if err := ctrl.Watch(src, &handler.EnqueueRequestForOwner{OwnerType: owner, IsController: true}); err != nil {
+		cancel()
+		return false, err
+	}
+	go func() {
+		if err := providerCache.Start(ctx); err != nil {
+			log.FromContext(ctx).Error(err, "Error starting the cache of the provider kind", "Kind", gvk.String())
+		}
+	}()
+
+	w.watches[key] = &providerWatch{cancel: cancel, providers: map[string]bool{provider: true}}
+	return true, nil
+}
+
+// release stops declaring the provider kinds for a provider, except the kept ones, and stops the watches
+// of the kinds that no provider declares anymore
+func (w *providerWatches) release(provider string, keep ...providerWatchKey) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	kept := map[providerWatchKey]bool{}
+	for _, key := range keep {
+		kept[key] = true
+	}
+	for key, pw := range w.watches {
+		if !pw.providers[provider] || kept[key] {
+			continue
+		}
+		delete(pw.providers, provider)
+		if len(pw.providers) == 0 {
+			pw.cancel()
+			delete(w.watches, key)
+		}
+	}
+}
+
+// isWatched returns true if the provider kind is watched by the DBaaS controller
+func (w *providerWatches) isWatched(ctrl controller.Controller, gvk schema.GroupVersionKind) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	_, ok := w.watches[providerWatchKey{ctrl: ctrl, gvk: gvk}]
+	return ok
+}
+
+// providerKindSource is a source of provider objects that stops when its context is cancelled,
+// instead of the context of the controller
+type providerKindSource struct {
+	*source.Kind
+	ctx context.Context
+}
+
+var _ source.SyncingSource = &providerKindSource{}
+
+// Start starts the source with the context of the watch
+func (s *providerKindSource) Start(_ context.Context, handler handler.EventHandler, queue workqueue.RateLimitingInterface,
+	prct ...predicate.Predicate) error {
+	return s.Kind.Start(s.ctx, handler, queue, prct...)
+}
+
+// WaitForSync ignores the sync errors of the watches stopped before the controller starts
+func (s *providerKindSource) WaitForSync(ctx context.Context) error {
+	err := s.Kind.WaitForSync(ctx)
+	if s.ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/providerapi"
+)
+
+// DBaaSRestoreReconciler reconciles a DBaaSRestore object
+type DBaaSRestoreReconciler struct {
+	*DBaaSReconciler
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.13.1/pkg/reconcile
+func (r *DBaaSRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var restore v1beta1.DBaaSRestore
+	if err := r.Get(ctx, req.NamespacedName, &restore); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, child objects getting GC'd, no requeue
+			logger.V(1).Info("DBaaS Restore resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Restore for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	setStatusCondition := func(reason string, message string) {
+		cond := metav1.Condition{
+			Type:    v1beta1.DBaaSRestoreReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		}
+		apimeta.SetStatusCondition(&restore.Status.Conditions, cond)
+	}
+
+	inventory, validNS, _, err := r.checkInventory(ctx, restore.Spec.InventoryRef, &restore, setStatusCondition, logger)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if !validNS {
+		return ctrl.Result{}, nil
+	}
+
+	provider, err := r.getDBaaSProvider(ctx, inventory.Spec.ProviderRef.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(provider.Spec.RestoreKind) == 0 {
+		logger.Info("DBaaS Provider does not support restores", "DBaaS Provider", provider.Name)
+		setStatusCondition(v1beta1.DBaaSProviderKindNotSupported, v1beta1.MsgRestoreNotSupported)
+		return ctrl.Result{}, r.updateDBaaSObjectStatus(ctx, &restore)
+	}
+
+	spec := restore.Spec.DeepCopy()
+	if len(spec.BackupID) == 0 {
+		backupRef, err := localRef(spec.BackupRef, restore.Namespace)
+		if err == nil {
+			spec.BackupID, err = r.getReferencedBackupID(ctx, spec.InventoryRef, backupRef)
+		}
+		if err != nil {
+			logger.Error(err, "Cannot read the backup reference")
+			setStatusCondition(v1beta1.DBaaSBackupNotAvailable, err.Error())
+			if errCond := r.updateDBaaSObjectStatus(ctx, &restore); errCond != nil {
+				logger.Error(errCond, "Error updating the DBaaS Restore status")
+			}
+			return ctrl.Result{}, err
+		}
+		spec.BackupRef = nil
+	}
+	if len(spec.DatabaseServiceID) == 0 {
+		instanceRef, err := localRef(spec.DatabaseServiceRef, restore.Namespace)
+		if err == nil {
+			spec.DatabaseServiceID, err = r.getReferencedInstanceID(ctx, spec.InventoryRef, instanceRef)
+		}
+		if err != nil {
+			logger.Error(err, "Cannot read the database service reference")
+			setStatusCondition(v1beta1.DBaaSServiceNotAvailable, err.Error())
+			if errCond := r.updateDBaaSObjectStatus(ctx, &restore); errCond != nil {
+				logger.Error(errCond, "Error updating the DBaaS Restore status")
+			}
+			return ctrl.Result{}, err
+		}
+		spec.DatabaseServiceRef = nil
+	}
+
+	converter, err := providerapi.ForProvider(provider)
+	if err != nil {
+		logger.Error(err, "Error converting the DBaaS Restore for the provider")
+		return ctrl.Result{}, err
+	}
+	providerSpec, err := converter.RestoreSpec(spec)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return r.reconcileProviderResource(ctx,
+		inventory.Spec.ProviderRef.Name,
+		&restore,
+		func(provider *v1beta1.DBaaSProvider) string {
+			return provider.Spec.RestoreKind
+		},
+		func() interface{} {
+			return providerSpec
+		},
+		converter.NewRestore,
+		func(i interface{}) metav1.Condition {
+			return mergeRestoreStatus(&restore, converter.ConvertRestore(i))
+		},
+		func() *[]metav1.Condition {
+			return &restore.Status.Conditions
+		},
+		v1beta1.DBaaSRestoreReadyType,
+		logger,
+	)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSRestoreReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.DBaaSRestore{}).
+		WithOptions(
+			controller.Options{MaxConcurrentReconciles: 2},
+		).
+		Build(r)
+}
+
+// getReferencedBackupID returns the backup ID of a completed DBaaSBackup that must use the given inventory
+func (r *DBaaSRestoreReconciler) getReferencedBackupID(ctx context.Context, inventoryRef v1beta1.NamespacedName, backupRef *v1beta1.NamespacedName) (string, error) {
+	if backupRef == nil || len(backupRef.Name) == 0 {
+		return "", fmt.Errorf("either backupID or backupRef must be specified")
+	}
+
+	backup := &v1beta1.DBaaSBackup{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      backupRef.Name,
+		Namespace: backupRef.Namespace,
+	}, backup); err != nil {
+		return "", fmt.Errorf("cannot read the backup reference")
+	}
+
+	if backup.Spec.InventoryRef.Namespace != inventoryRef.Namespace ||
+		backup.Spec.InventoryRef.Name != inventoryRef.Name {
+		return "", fmt.Errorf("backup and restore don't use the same inventory reference")
+	}
+
+	if backup.Status.Phase != v1beta1.BackupPhaseCompleted || len(backup.Status.BackupID) == 0 {
+		return "", fmt.Errorf("backup %s is not completed", backupRef.Name)
+	}
+	return backup.Status.BackupID, nil
+}
+
+// mergeRestoreStatus: merge the status from DBaaSProviderRestore into the current DBaaSRestore status
+func mergeRestoreStatus(restore *v1beta1.DBaaSRestore, providerRestore *v1beta1.DBaaSProviderRestore) metav1.Condition {
+	providerRestore.Status.DeepCopyInto(&restore.Status)
+	if len(restore.Status.Phase) == 0 {
+		restore.Status.Phase = v1beta1.RestorePhaseUnknown
+	}
+	// Update restore status condition (type: DBaaSRestoreReadyType) based on the provider status
+	specSync := apimeta.FindStatusCondition(providerRestore.Status.Conditions, v1beta1.DBaaSRestoreProviderSyncType)
+	if specSync != nil && specSync.Status == metav1.ConditionTrue {
+		return metav1.Condition{
+			Type:    v1beta1.DBaaSRestoreReadyType,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1beta1.Ready,
+			Message: v1beta1.MsgProviderCRStatusSyncDone,
+		}
+	}
+	return metav1.Condition{
+		Type:    v1beta1.DBaaSRestoreReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1beta1.ProviderReconcileInprogress,
+		Message: v1beta1.MsgProviderCRReconcileInProgress,
+	}
+}
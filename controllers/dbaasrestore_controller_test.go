@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+var _ = Describe("DBaaSRestore controller", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(backupProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1beta1.Ready))
+
+	Context("after creating DBaaSRestore for a provider without restore support", func() {
+		inventoryName := "test-restore-inventory-not-supported"
+		createdDBaaSInventory := &v1beta1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      inventoryName,
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1beta1.NamespacedName{
+					Name: testBackupProviderName,
+				},
+				DBaaSInventorySpec: v1beta1.DBaaSInventorySpec{
+					CredentialsRef: &v1beta1.LocalObjectReference{
+						Name: testSecret.Name,
+					},
+				},
+			},
+		}
+		lastTransitionTime := getLastTransitionTimeForTest()
+		providerInventoryStatus := &v1beta1.DBaaSInventoryStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               "SpecSynced",
+					Status:             metav1.ConditionTrue,
+					Reason:             "SyncOK",
+					LastTransitionTime: metav1.Time{Time: lastTransitionTime},
+				},
+			},
+		}
+		createdDBaaSRestore := &v1beta1.DBaaSRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-restore-not-supported",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSRestoreSpec{
+				InventoryRef: v1beta1.NamespacedName{
+					Name:      inventoryName,
+					Namespace: testNamespace,
+				},
+				BackupID:          "test-backup-id",
+				DatabaseServiceID: "testInstanceID",
+			},
+		}
+
+		BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, backupProvider.GetDBaaSAPIGroupVersion(), metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
+		BeforeEach(assertResourceCreation(createdDBaaSRestore))
+		AfterEach(assertResourceDeletion(createdDBaaSRestore))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+		It("should report that restores are not supported", assertDBaaSResourceStatusUpdated(createdDBaaSRestore, metav1.ConditionFalse, v1beta1.DBaaSProviderKindNotSupported))
+	})
+})
+
+var _ = Describe("Merge DBaaSRestore status", func() {
+	It("should copy the provider restore status and set the ready condition", func() {
+		restore := &v1beta1.DBaaSRestore{}
+		providerRestore := &v1beta1.DBaaSProviderRestore{
+			Status: v1beta1.DBaaSRestoreStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   v1beta1.DBaaSRestoreProviderSyncType,
+						Status: metav1.ConditionTrue,
+						Reason: "SyncOK",
+					},
+				},
+				Phase: v1beta1.RestorePhaseInProgress,
+			},
+		}
+
+		cond := mergeRestoreStatus(restore, providerRestore)
+		Expect(cond.Type).Should(Equal(v1beta1.DBaaSRestoreReadyType))
+		Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(restore.Status.Phase).Should(Equal(v1beta1.RestorePhaseInProgress))
+	})
+})
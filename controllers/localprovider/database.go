@@ -0,0 +1,252 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localprovider
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+const (
+	// usernameKey and passwordKey are the entries of the credentials secrets
+	usernameKey = "username"
+	passwordKey = "password"
+	// rootPasswordKey is the entry of the instance credentials secret with the password of the administrator,
+	// it is not copied to the connections
+	rootPasswordKey = "root-password"
+
+	// defaultUsername is the database user created for the applications
+	defaultUsername = "dbaas"
+	// defaultStorageGib is the size of the database volume when the storage is not set
+	defaultStorageGib = 1
+
+	// instanceLabel is set on the objects of an instance, with the instance name as value
+	instanceLabel = "dbaas.redhat.com/local-instance"
+)
+
+// engine defines how a database type runs inside the cluster
+type engine struct {
+	databaseType string
+	imageEnvVar  string
+	defaultImage string
+	port         int32
+	dataPath     string
+	env          func(secretName, database string) []corev1.EnvVar
+	readyCommand string
+}
+
+// engines are the database types supported by the local provider, PostgreSQL is the default
+var engines = map[string]engine{
+	"postgresql": {
+		databaseType: "postgresql",
+		imageEnvVar:  "RELATED_IMAGE_LOCAL_POSTGRESQL",
+		defaultImage: "docker.io/library/postgres:15",
+		port:         5432,
+		dataPath:     "/var/lib/postgresql/data",
+		env: func(secretName, database string) []corev1.EnvVar {
+			return []corev1.EnvVar{
+				secretEnvVar("POSTGRES_USER", secretName, usernameKey),
+				secretEnvVar("POSTGRES_PASSWORD", secretName, passwordKey),
+				{Name: "POSTGRES_DB", Value: database},
+				{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+			}
+		},
+		readyCommand: `pg_isready -h 127.0.0.1 -U "$POSTGRES_USER" -d "$POSTGRES_DB"`,
+	},
+	"mysql": {
+		databaseType: "mysql",
+		imageEnvVar:  "RELATED_IMAGE_LOCAL_MYSQL",
+		defaultImage: "docker.io/library/mysql:8.0",
+		port:         3306,
+		dataPath:     "/var/lib/mysql",
+		env: func(secretName, database string) []corev1.EnvVar {
+			return []corev1.EnvVar{
+				secretEnvVar("MYSQL_USER", secretName, usernameKey),
+				secretEnvVar("MYSQL_PASSWORD", secretName, passwordKey),
+				secretEnvVar("MYSQL_ROOT_PASSWORD", secretName, rootPasswordKey),
+				{Name: "MYSQL_DATABASE", Value: database},
+			}
+		},
+		readyCommand: `mysqladmin ping -h 127.0.0.1 -u "$MYSQL_USER" -p"$MYSQL_PASSWORD"`,
+	},
+}
+
+// getEngine returns the engine of the database type of an instance
+func getEngine(instance *v1beta1.LocalInstance) (engine, error) {
+	databaseType := instance.Spec.ProvisioningParameters[v1beta1.ProvisioningDatabaseType]
+	if len(databaseType) == 0 {
+		return engines["postgresql"], nil
+	}
+	if databaseType == "postgres" {
+		databaseType = "postgresql"
+	}
+	e, ok := engines[databaseType]
+	if !ok {
+		return engine{}, fmt.Errorf("database type %s is not supported, the supported types are postgresql and mysql", databaseType)
+	}
+	return e, nil
+}
+
+// image returns the image of the engine, it can be replaced with an environment variable for disconnected clusters
+func (e engine) image() string {
+	if image := os.Getenv(e.imageEnvVar); len(image) > 0 {
+		return image
+	}
+	return e.defaultImage
+}
+
+// databaseName returns the name of the database created in an instance
+func databaseName(instance *v1beta1.LocalInstance) string {
+	if name := instance.Spec.ProvisioningParameters[v1beta1.ProvisioningName]; len(name) > 0 {
+		return name
+	}
+	return instance.Name
+}
+
+// storageSize returns the size of the database volume of an instance
+func storageSize(instance *v1beta1.LocalInstance) (resource.Quantity, error) {
+	gib := defaultStorageGib
+	if value := instance.Spec.ProvisioningParameters[v1beta1.ProvisioningStorageGib]; len(value) > 0 {
+		var err error
+		if gib, err = strconv.Atoi(value); err != nil || gib <= 0 {
+			return resource.Quantity{}, fmt.Errorf("invalid storage size %s, a positive number of GiB is expected", value)
+		}
+	}
+	return resource.MustParse(fmt.Sprintf("%dGi", gib)), nil
+}
+
+// credentialsSecretName returns the name of the secret with the database credentials of an instance.
+// It differs from the secret name of a connection, as a connection often has the name of its instance.
+func credentialsSecretName(instance *v1beta1.LocalInstance) string {
+	return instance.Name + "-instance-credentials"
+}
+
+// connectionCredentialsSecretName returns the name of the secret with the database credentials of a connection
+func connectionCredentialsSecretName(connection *v1beta1.LocalConnection) string {
+	return connection.Name + "-connection-credentials"
+}
+
+// serviceHost returns the host name of the database service of an instance
+func serviceHost(instance *v1beta1.LocalInstance) string {
+	return fmt.Sprintf("%s.%s.svc", instance.Name, instance.Namespace)
+}
+
+// generatePassword returns a random password
+func generatePassword() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// mutateService sets the fields of the database service of an instance owned by the local provider,
+// the other fields and the defaults set by the API server are kept
+func mutateService(service *corev1.Service, instance *v1beta1.LocalInstance, e engine) {
+	setInstanceLabel(&service.ObjectMeta, instance)
+	service.Spec.Selector = map[string]string{instanceLabel: instance.Name}
+	if len(service.Spec.Ports) != 1 || service.Spec.Ports[0].Name != e.databaseType {
+		service.Spec.Ports = []corev1.ServicePort{{Name: e.databaseType}}
+	}
+	port := &service.Spec.Ports[0]
+	port.Port = e.port
+	port.TargetPort = intstr.FromInt(int(e.port))
+	port.Protocol = corev1.ProtocolTCP
+}
+
+// mutateStatefulSet sets the fields of the database StatefulSet of an instance owned by the local provider, the other fields
+// and the defaults set by the API server are kept. The selector and volume claim are only set on creation as they are immutable.
+func mutateStatefulSet(statefulSet *appsv1.StatefulSet, instance *v1beta1.LocalInstance, e engine, storage resource.Quantity) {
+	labels := map[string]string{instanceLabel: instance.Name}
+	replicas := int32(1)
+	setInstanceLabel(&statefulSet.ObjectMeta, instance)
+	statefulSet.Spec.Replicas = &replicas
+	if len(statefulSet.ResourceVersion) == 0 {
+		statefulSet.Spec.ServiceName = instance.Name
+		statefulSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+		statefulSet.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{{
+			ObjectMeta: metav1.ObjectMeta{Name: "data"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: storage},
+				},
+			},
+		}}
+	}
+	setInstanceLabel(&statefulSet.Spec.Template.ObjectMeta, instance)
+
+	container := databaseContainer(&statefulSet.Spec.Template.Spec)
+	container.Image = e.image()
+	container.Env = e.env(credentialsSecretName(instance), databaseName(instance))
+	container.Ports = []corev1.ContainerPort{{
+		Name:          e.databaseType,
+		ContainerPort: e.port,
+		Protocol:      corev1.ProtocolTCP,
+	}}
+	if container.ReadinessProbe == nil {
+		container.ReadinessProbe = &corev1.Probe{}
+	}
+	container.ReadinessProbe.ProbeHandler = corev1.ProbeHandler{
+		Exec: &corev1.ExecAction{Command: []string{"sh", "-c", e.readyCommand}},
+	}
+	container.ReadinessProbe.InitialDelaySeconds = 5
+	container.ReadinessProbe.PeriodSeconds = 10
+	container.VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: e.dataPath}}
+}
+
+// databaseContainer returns the database container of a pod spec, it is added when missing
+func databaseContainer(podSpec *corev1.PodSpec) *corev1.Container {
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == "database" {
+			return &podSpec.Containers[i]
+		}
+	}
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{Name: "database"})
+	return &podSpec.Containers[len(podSpec.Containers)-1]
+}
+
+// setInstanceLabel sets the instance label, the other labels are kept
+func setInstanceLabel(objectMeta *metav1.ObjectMeta, instance *v1beta1.LocalInstance) {
+	if objectMeta.Labels == nil {
+		objectMeta.Labels = map[string]string{}
+	}
+	objectMeta.Labels[instanceLabel] = instance.Name
+}
+
+func secretEnvVar(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
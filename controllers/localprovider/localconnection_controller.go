@@ -0,0 +1,211 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localprovider
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// LocalConnectionReconciler issues the credentials and connection information of the LocalConnections
+type LocalConnectionReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localconnections,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localconnections/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile copies the credentials of the database instance of a LocalConnection, and writes its connection information
+func (r *LocalConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	var connection v1beta1.LocalConnection
+	if err := r.Get(ctx, req.NamespacedName, &connection); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching Local Connection for reconcile")
+		return ctrl.Result{}, err
+	}
+	if connection.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	status := connection.Status.DeepCopy()
+	instance, err := r.getConnectionInstance(ctx, &connection)
+	if err != nil {
+		logger.Error(err, "Error fetching the Local Instance of the Local Connection")
+		return ctrl.Result{}, err
+	}
+	if instance == nil {
+		setConnectionCondition(status, metav1.ConditionFalse, v1beta1.DBaaSServiceNotAvailable, "The database instance is not found")
+		return ctrl.Result{}, r.updateStatus(ctx, &connection, status)
+	}
+	if instance.Status.Phase != v1beta1.InstancePhaseReady {
+		setConnectionCondition(status, metav1.ConditionFalse, v1beta1.ProviderReconcileInprogress, "Waiting for the database instance to be ready")
+		return ctrl.Result{}, r.updateStatus(ctx, &connection, status)
+	}
+
+	instanceSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: credentialsSecretName(instance), Namespace: instance.Namespace}, instanceSecret); err != nil {
+		logger.Error(err, "Error fetching the credentials of the Local Instance")
+		return ctrl.Result{}, err
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: connectionCredentialsSecretName(&connection), Namespace: connection.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.Data = map[string][]byte{
+			usernameKey: instanceSecret.Data[usernameKey],
+			passwordKey: instanceSecret.Data[passwordKey],
+		}
+		return controllerutil.SetControllerReference(&connection, secret, r.Scheme)
+	}); err != nil {
+		logger.Error(err, "Error reconciling the credentials of the Local Connection")
+		return ctrl.Result{}, err
+	}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: connection.Name + "-info", Namespace: connection.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Data = getConnectionInfo(instance)
+		return controllerutil.SetControllerReference(&connection, configMap, r.Scheme)
+	}); err != nil {
+		logger.Error(err, "Error reconciling the connection information of the Local Connection")
+		return ctrl.Result{}, err
+	}
+
+	status.CredentialsRef = &corev1.LocalObjectReference{Name: secret.Name}
+	status.ConnectionInfoRef = &corev1.LocalObjectReference{Name: configMap.Name}
+	setConnectionCondition(status, metav1.ConditionTrue, v1beta1.Ready, "The connection is ready for binding")
+	return ctrl.Result{}, r.updateStatus(ctx, &connection, status)
+}
+
+// getConnectionInstance returns the instance of a connection, by database service ID or by reference, or nil if it is not found
+func (r *LocalConnectionReconciler) getConnectionInstance(ctx context.Context, connection *v1beta1.LocalConnection) (*v1beta1.LocalInstance, error) {
+	inventoryNamespace := connection.Spec.InventoryRef.Namespace
+	if len(inventoryNamespace) == 0 {
+		inventoryNamespace = connection.Namespace
+	}
+	var instanceList v1beta1.LocalInstanceList
+	if err := r.List(ctx, &instanceList); err != nil {
+		return nil, err
+	}
+	return findConnectionInstance(connection, inventoryNamespace, instanceList.Items), nil
+}
+
+// findConnectionInstance returns the instance of the inventory matching the database service of a connection
+func findConnectionInstance(connection *v1beta1.LocalConnection, inventoryNamespace string, instances []v1beta1.LocalInstance) *v1beta1.LocalInstance {
+	for i := range instances {
+		instance := &instances[i]
+		if !isInventoryInstance(connection.Spec.InventoryRef.Name, inventoryNamespace, instance) {
+			continue
+		}
+		if len(connection.Spec.DatabaseServiceID) > 0 {
+			if instance.Status.InstanceID == connection.Spec.DatabaseServiceID {
+				return instance
+			}
+			continue
+		}
+		if ref := connection.Spec.DatabaseServiceRef; ref != nil && ref.Name == instance.Name {
+			namespace := ref.Namespace
+			if len(namespace) == 0 {
+				namespace = connection.Namespace
+			}
+			if namespace == instance.Namespace {
+				return instance
+			}
+		}
+	}
+	return nil
+}
+
+// getConnectionInfo returns the non-sensitive information for connecting to the database of an instance
+func getConnectionInfo(instance *v1beta1.LocalInstance) map[string]string {
+	return map[string]string{
+		"type":     instance.Status.InstanceInfo["databaseType"],
+		"provider": "Local",
+		"host":     instance.Status.InstanceInfo["host"],
+		"port":     instance.Status.InstanceInfo["port"],
+		"database": instance.Status.InstanceInfo["database"],
+	}
+}
+
+func setConnectionCondition(status *v1beta1.DBaaSConnectionStatus, conditionStatus metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSConnectionProviderSyncType,
+		Status:  conditionStatus,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func (r *LocalConnectionReconciler) updateStatus(ctx context.Context, connection *v1beta1.LocalConnection, status *v1beta1.DBaaSConnectionStatus) error {
+	if reflect.DeepEqual(&connection.Status, status) {
+		return nil
+	}
+	status.DeepCopyInto(&connection.Status)
+	return r.Status().Update(ctx, connection)
+}
+
+// instanceConnectionRequests enqueues the connections to the inventory of an instance
+func (r *LocalConnectionReconciler) instanceConnectionRequests(o client.Object) []reconcile.Request {
+	instance, ok := o.(*v1beta1.LocalInstance)
+	if !ok {
+		return nil
+	}
+	var connectionList v1beta1.LocalConnectionList
+	if err := r.List(context.Background(), &connectionList); err != nil {
+		ctrl.Log.Error(err, "Error listing Local Connections for the Local Instance change", "instance", instance.Name)
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range connectionList.Items {
+		connection := &connectionList.Items[i]
+		inventoryNamespace := connection.Spec.InventoryRef.Namespace
+		if len(inventoryNamespace) == 0 {
+			inventoryNamespace = connection.Namespace
+		}
+		if isInventoryInstance(connection.Spec.InventoryRef.Name, inventoryNamespace, instance) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(connection)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LocalConnectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.LocalConnection{}).
+		Owns(&corev1.Secret{}, builder.OnlyMetadata).
+		Owns(&corev1.ConfigMap{}, builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &v1beta1.LocalInstance{}}, handler.EnqueueRequestsFromMapFunc(r.instanceConnectionRequests)).
+		Complete(r)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localprovider
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// LocalInstanceReconciler runs the databases of the LocalInstances as StatefulSets
+type LocalInstanceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localinstances,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localinstances/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile creates the credentials, service and StatefulSet of a LocalInstance, and reports the database in its status
+func (r *LocalInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	var instance v1beta1.LocalInstance
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if errors.IsNotFound(err) {
+			// the database objects are deleted by the garbage collector
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching Local Instance for reconcile")
+		return ctrl.Result{}, err
+	}
+	if instance.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	status := instance.Status.DeepCopy()
+	status.InstanceID = string(instance.UID)
+	e, err := getEngine(&instance)
+	if err != nil {
+		return ctrl.Result{}, r.updateFailedStatus(ctx, &instance, status, err)
+	}
+	storage, err := storageSize(&instance)
+	if err != nil {
+		return ctrl.Result{}, r.updateFailedStatus(ctx, &instance, status, err)
+	}
+
+	if err := r.reconcileCredentials(ctx, &instance); err != nil {
+		logger.Error(err, "Error reconciling the credentials of the Local Instance")
+		return ctrl.Result{}, err
+	}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		mutateService(service, &instance, e)
+		return controllerutil.SetControllerReference(&instance, service, r.Scheme)
+	}); err != nil {
+		logger.Error(err, "Error reconciling the service of the Local Instance")
+		return ctrl.Result{}, err
+	}
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, statefulSet, func() error {
+		mutateStatefulSet(statefulSet, &instance, e, storage)
+		return controllerutil.SetControllerReference(&instance, statefulSet, r.Scheme)
+	}); err != nil {
+		logger.Error(err, "Error reconciling the StatefulSet of the Local Instance")
+		return ctrl.Result{}, err
+	}
+
+	status.InstanceInfo = map[string]string{
+		"host":         serviceHost(&instance),
+		"port":         strconv.Itoa(int(e.port)),
+		"databaseType": e.databaseType,
+		"database":     databaseName(&instance),
+	}
+	if statefulSet.Status.ReadyReplicas > 0 {
+		status.Phase = v1beta1.InstancePhaseReady
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    v1beta1.DBaaSInstanceProviderSyncType,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1beta1.Ready,
+			Message: "The database is ready",
+		})
+	} else {
+		status.Phase = v1beta1.InstancePhaseCreating
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    v1beta1.DBaaSInstanceProviderSyncType,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1beta1.ProviderReconcileInprogress,
+			Message: "Waiting for the database to be ready",
+		})
+	}
+	return ctrl.Result{}, r.updateStatus(ctx, &instance, status)
+}
+
+// reconcileCredentials creates the secret with the database credentials of an instance, the passwords are only generated once
+func (r *LocalInstanceReconciler) reconcileCredentials(ctx context.Context, instance *v1beta1.LocalInstance) error {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: credentialsSecretName(instance), Namespace: instance.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		if len(secret.Data[usernameKey]) == 0 {
+			secret.Data[usernameKey] = []byte(defaultUsername)
+		}
+		for _, key := range []string{passwordKey, rootPasswordKey} {
+			if len(secret.Data[key]) == 0 {
+				password, err := generatePassword()
+				if err != nil {
+					return err
+				}
+				secret.Data[key] = []byte(password)
+			}
+		}
+		return controllerutil.SetControllerReference(instance, secret, r.Scheme)
+	})
+	return err
+}
+
+// updateFailedStatus reports invalid provisioning parameters, the instance is not provisioned until they are fixed
+func (r *LocalInstanceReconciler) updateFailedStatus(ctx context.Context, instance *v1beta1.LocalInstance, status *v1beta1.DBaaSInstanceStatus, err error) error {
+	status.Phase = v1beta1.InstancePhaseFailed
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSInstanceProviderSyncType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1beta1.ProviderReconcileError,
+		Message: err.Error(),
+	})
+	return r.updateStatus(ctx, instance, status)
+}
+
+func (r *LocalInstanceReconciler) updateStatus(ctx context.Context, instance *v1beta1.LocalInstance, status *v1beta1.DBaaSInstanceStatus) error {
+	if reflect.DeepEqual(&instance.Status, status) {
+		return nil
+	}
+	status.DeepCopyInto(&instance.Status)
+	return r.Status().Update(ctx, instance)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LocalInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.LocalInstance{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&corev1.Service{}).
+		Complete(r)
+}
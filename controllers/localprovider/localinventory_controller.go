@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localprovider
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// instanceServiceType is the type of the database services reported for the LocalInstances
+var instanceServiceType v1beta1.DatabaseServiceType = "instance"
+
+// LocalInventoryReconciler reports the databases of the LocalInstances in the LocalInventories
+type LocalInventoryReconciler struct {
+	client.Client
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localinventories,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localinventories/status,verbs=get;update;patch
+
+// Reconcile lists the LocalInstances of a LocalInventory as its database services
+func (r *LocalInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	var inventory v1beta1.LocalInventory
+	if err := r.Get(ctx, req.NamespacedName, &inventory); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching Local Inventory for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	var instanceList v1beta1.LocalInstanceList
+	if err := r.List(ctx, &instanceList); err != nil {
+		logger.Error(err, "Error listing the Local Instances of the Local Inventory")
+		return ctrl.Result{}, err
+	}
+
+	status := inventory.Status.DeepCopy()
+	status.DatabaseServices = getDatabaseServices(&inventory, instanceList.Items)
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSInventoryProviderSyncType,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1beta1.Ready,
+		Message: "The databases of the inventory are listed",
+	})
+	if reflect.DeepEqual(&inventory.Status, status) {
+		return ctrl.Result{}, nil
+	}
+	status.DeepCopyInto(&inventory.Status)
+	return ctrl.Result{}, r.Status().Update(ctx, &inventory)
+}
+
+// getDatabaseServices returns the database services of the instances referencing an inventory, sorted by name
+func getDatabaseServices(inventory *v1beta1.LocalInventory, instances []v1beta1.LocalInstance) []v1beta1.DatabaseService {
+	var services []v1beta1.DatabaseService
+	for i := range instances {
+		instance := &instances[i]
+		if !isInventoryInstance(inventory.Name, inventory.Namespace, instance) || len(instance.Status.InstanceID) == 0 {
+			continue
+		}
+		serviceInfo := map[string]string{
+			"namespace": instance.Namespace,
+			"phase":     string(instance.Status.Phase),
+		}
+		for key, value := range instance.Status.InstanceInfo {
+			serviceInfo[key] = value
+		}
+		services = append(services, v1beta1.DatabaseService{
+			ServiceID:   instance.Status.InstanceID,
+			ServiceName: instance.Name,
+			ServiceType: &instanceServiceType,
+			ServiceInfo: serviceInfo,
+		})
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ServiceName < services[j].ServiceName
+	})
+	return services
+}
+
+// isInventoryInstance returns true if an instance references the inventory, in the namespace of the instance by default
+func isInventoryInstance(name, namespace string, instance *v1beta1.LocalInstance) bool {
+	inventoryNamespace := instance.Spec.InventoryRef.Namespace
+	if len(inventoryNamespace) == 0 {
+		inventoryNamespace = instance.Namespace
+	}
+	return instance.Spec.InventoryRef.Name == name && inventoryNamespace == namespace
+}
+
+// instanceInventoryRequests enqueues the inventory of an instance
+func instanceInventoryRequests(o client.Object) []reconcile.Request {
+	instance, ok := o.(*v1beta1.LocalInstance)
+	if !ok || len(instance.Spec.InventoryRef.Name) == 0 {
+		return nil
+	}
+	namespace := instance.Spec.InventoryRef.Namespace
+	if len(namespace) == 0 {
+		namespace = instance.Namespace
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: instance.Spec.InventoryRef.Name, Namespace: namespace}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LocalInventoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.LocalInventory{}).
+		Watches(&source.Kind{Type: &v1beta1.LocalInstance{}}, handler.EnqueueRequestsFromMapFunc(instanceInventoryRequests)).
+		Complete(r)
+}
@@ -0,0 +1,13 @@
+package localprovider
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestLocalProvider(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Local Provider Suite")
+}
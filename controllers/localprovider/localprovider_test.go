@@ -0,0 +1,291 @@
+package localprovider
+
+import (
+	"context"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+func newInstance(name, namespace, inventory string, params map[v1beta1.ProvisioningParameterType]string) *v1beta1.LocalInstance {
+	return &v1beta1.LocalInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1beta1.DBaaSInstanceSpec{
+			InventoryRef:           v1beta1.NamespacedName{Name: inventory, Namespace: namespace},
+			ProvisioningParameters: params,
+		},
+		Status: v1beta1.DBaaSInstanceStatus{
+			InstanceID: name + "-id",
+			Phase:      v1beta1.InstancePhaseReady,
+			InstanceInfo: map[string]string{
+				"host":         name + "." + namespace + ".svc",
+				"port":         "5432",
+				"databaseType": "postgresql",
+				"database":     name,
+			},
+		},
+	}
+}
+
+var _ = Describe("Database engines", func() {
+	It("should run PostgreSQL by default", func() {
+		instance := newInstance("test-instance", "test-ns", "test-inventory", nil)
+		e, err := getEngine(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.databaseType).To(Equal("postgresql"))
+		Expect(e.port).To(BeEquivalentTo(5432))
+		Expect(databaseName(instance)).To(Equal("test-instance"))
+		Expect(serviceHost(instance)).To(Equal("test-instance.test-ns.svc"))
+		size, err := storageSize(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(size.Equal(resource.MustParse("1Gi"))).To(BeTrue())
+	})
+
+	It("should run MySQL with the provisioning parameters", func() {
+		instance := newInstance("test-instance", "test-ns", "test-inventory", map[v1beta1.ProvisioningParameterType]string{
+			v1beta1.ProvisioningDatabaseType: "mysql",
+			v1beta1.ProvisioningName:         "appdb",
+			v1beta1.ProvisioningStorageGib:   "5",
+		})
+		e, err := getEngine(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.databaseType).To(Equal("mysql"))
+		Expect(e.port).To(BeEquivalentTo(3306))
+		Expect(databaseName(instance)).To(Equal("appdb"))
+		size, err := storageSize(instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(size.Equal(resource.MustParse("5Gi"))).To(BeTrue())
+	})
+
+	It("should reject the invalid provisioning parameters", func() {
+		_, err := getEngine(newInstance("test-instance", "test-ns", "test-inventory", map[v1beta1.ProvisioningParameterType]string{
+			v1beta1.ProvisioningDatabaseType: "oracle",
+		}))
+		Expect(err).To(MatchError("database type oracle is not supported, the supported types are postgresql and mysql"))
+		_, err = storageSize(newInstance("test-instance", "test-ns", "test-inventory", map[v1beta1.ProvisioningParameterType]string{
+			v1beta1.ProvisioningStorageGib: "-1",
+		}))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should use the image set in the environment", func() {
+		e := engines["postgresql"]
+		Expect(e.image()).To(Equal("docker.io/library/postgres:15"))
+		Expect(os.Setenv(e.imageEnvVar, "registry.example.com/postgres:15")).To(Succeed())
+		defer os.Unsetenv(e.imageEnvVar)
+		Expect(e.image()).To(Equal("registry.example.com/postgres:15"))
+	})
+
+	It("should set the StatefulSet and service of the instance", func() {
+		instance := newInstance("test-instance", "test-ns", "test-inventory", nil)
+		e := engines["postgresql"]
+		statefulSet := &appsv1.StatefulSet{}
+		mutateStatefulSet(statefulSet, instance, e, resource.MustParse("2Gi"))
+		Expect(*statefulSet.Spec.Replicas).To(BeEquivalentTo(1))
+		Expect(statefulSet.Spec.Selector.MatchLabels).To(Equal(map[string]string{instanceLabel: "test-instance"}))
+		Expect(statefulSet.Spec.VolumeClaimTemplates).To(HaveLen(1))
+		container := statefulSet.Spec.Template.Spec.Containers[0]
+		Expect(container.Image).To(Equal(e.defaultImage))
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "POSTGRES_DB", Value: "test-instance"}))
+		Expect(container.Env[0].ValueFrom.SecretKeyRef.Name).To(Equal("test-instance-instance-credentials"))
+
+		// the volume claim templates are immutable
+		statefulSet.ResourceVersion = "1"
+		statefulSet.Spec.VolumeClaimTemplates = nil
+		mutateStatefulSet(statefulSet, instance, e, resource.MustParse("2Gi"))
+		Expect(statefulSet.Spec.VolumeClaimTemplates).To(BeEmpty())
+
+		service := &corev1.Service{}
+		mutateService(service, instance, e)
+		Expect(service.Spec.Selector).To(Equal(map[string]string{instanceLabel: "test-instance"}))
+		Expect(service.Spec.Ports[0].Port).To(BeEquivalentTo(5432))
+	})
+
+	It("should keep the fields not owned by the local provider", func() {
+		instance := newInstance("test-instance", "test-ns", "test-inventory", nil)
+		e := engines["postgresql"]
+		statefulSet := &appsv1.StatefulSet{}
+		mutateStatefulSet(statefulSet, instance, e, resource.MustParse("2Gi"))
+
+		// defaults set by the API server, and a label set by another controller
+		statefulSet.ResourceVersion = "1"
+		statefulSet.Labels["example.com/team"] = "test"
+		container := &statefulSet.Spec.Template.Spec.Containers[0]
+		container.ImagePullPolicy = corev1.PullIfNotPresent
+		container.TerminationMessagePath = corev1.TerminationMessagePathDefault
+		container.ReadinessProbe.TimeoutSeconds = 1
+		expected := statefulSet.DeepCopy()
+		mutateStatefulSet(statefulSet, instance, e, resource.MustParse("2Gi"))
+		Expect(statefulSet).To(Equal(expected))
+
+		service := &corev1.Service{}
+		mutateService(service, instance, e)
+		service.Spec.Ports[0].NodePort = 30432
+		service.Labels["example.com/team"] = "test"
+		expectedService := service.DeepCopy()
+		mutateService(service, instance, e)
+		Expect(service).To(Equal(expectedService))
+	})
+
+	It("should not share the user password with the MySQL administrator", func() {
+		instance := newInstance("test-instance", "test-ns", "test-inventory", nil)
+		env := engines["mysql"].env(credentialsSecretName(instance), databaseName(instance))
+		Expect(env).To(ContainElement(secretEnvVar("MYSQL_PASSWORD", "test-instance-instance-credentials", passwordKey)))
+		Expect(env).To(ContainElement(secretEnvVar("MYSQL_ROOT_PASSWORD", "test-instance-instance-credentials", rootPasswordKey)))
+	})
+
+	It("should generate random passwords", func() {
+		password, err := generatePassword()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(password).To(HaveLen(32))
+		other, err := generatePassword()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other).NotTo(Equal(password))
+	})
+})
+
+var _ = Describe("Inventory database services", func() {
+	It("should list the instances of the inventory", func() {
+		inventory := &v1beta1.LocalInventory{ObjectMeta: metav1.ObjectMeta{Name: "test-inventory", Namespace: "test-ns"}}
+		pending := newInstance("pending-instance", "test-ns", "test-inventory", nil)
+		pending.Status = v1beta1.DBaaSInstanceStatus{}
+		services := getDatabaseServices(inventory, []v1beta1.LocalInstance{
+			*newInstance("b-instance", "test-ns", "test-inventory", nil),
+			*newInstance("a-instance", "test-ns", "test-inventory", nil),
+			*newInstance("other-instance", "test-ns", "other-inventory", nil),
+			*newInstance("c-instance", "other-ns", "test-inventory", nil),
+			*pending,
+		})
+		Expect(services).To(HaveLen(2))
+		Expect(services[0].ServiceID).To(Equal("a-instance-id"))
+		Expect(services[0].ServiceName).To(Equal("a-instance"))
+		Expect(*services[0].ServiceType).To(Equal(instanceServiceType))
+		Expect(services[0].ServiceInfo).To(HaveKeyWithValue("host", "a-instance.test-ns.svc"))
+		Expect(services[0].ServiceInfo).To(HaveKeyWithValue("phase", "Ready"))
+		Expect(services[1].ServiceName).To(Equal("b-instance"))
+	})
+
+	It("should enqueue the inventory of an instance", func() {
+		instance := newInstance("test-instance", "test-ns", "test-inventory", nil)
+		instance.Spec.InventoryRef.Namespace = ""
+		requests := instanceInventoryRequests(instance)
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Name).To(Equal("test-inventory"))
+		Expect(requests[0].Namespace).To(Equal("test-ns"))
+	})
+})
+
+var _ = Describe("Connection instance", func() {
+	instances := []v1beta1.LocalInstance{
+		*newInstance("a-instance", "inventory-ns", "test-inventory", nil),
+		*newInstance("b-instance", "inventory-ns", "test-inventory", nil),
+		*newInstance("c-instance", "inventory-ns", "other-inventory", nil),
+	}
+	newConnection := func() *v1beta1.LocalConnection {
+		return &v1beta1.LocalConnection{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-connection", Namespace: "app-ns"},
+			Spec: v1beta1.DBaaSConnectionSpec{
+				InventoryRef: v1beta1.NamespacedName{Name: "test-inventory", Namespace: "inventory-ns"},
+			},
+		}
+	}
+
+	It("should find the instance by database service ID", func() {
+		connection := newConnection()
+		connection.Spec.DatabaseServiceID = "b-instance-id"
+		Expect(findConnectionInstance(connection, "inventory-ns", instances).Name).To(Equal("b-instance"))
+		connection.Spec.DatabaseServiceID = "c-instance-id"
+		Expect(findConnectionInstance(connection, "inventory-ns", instances)).To(BeNil())
+	})
+
+	It("should find the instance by reference", func() {
+		connection := newConnection()
+		connection.Spec.DatabaseServiceRef = &v1beta1.NamespacedName{Name: "a-instance", Namespace: "inventory-ns"}
+		Expect(findConnectionInstance(connection, "inventory-ns", instances).Name).To(Equal("a-instance"))
+		connection.Spec.DatabaseServiceRef.Namespace = ""
+		Expect(findConnectionInstance(connection, "inventory-ns", instances)).To(BeNil())
+	})
+
+	It("should return the connection information of the instance", func() {
+		Expect(getConnectionInfo(&instances[0])).To(Equal(map[string]string{
+			"type":     "postgresql",
+			"provider": "Local",
+			"host":     "a-instance.inventory-ns.svc",
+			"port":     "5432",
+			"database": "a-instance",
+		}))
+	})
+})
+
+var _ = Describe("Provider registration", func() {
+	It("should register the local kinds", func() {
+		provider := getProvider()
+		mutateProvider(provider)
+		Expect(provider.Name).To(Equal(v1beta1.LocalRegistration))
+		Expect(provider.Spec.GroupVersion).To(Equal("dbaas.redhat.com/v1beta1"))
+		Expect(provider.Spec.InventoryKind).To(Equal(v1beta1.LocalInventoryKind))
+		Expect(provider.Spec.ConnectionKind).To(Equal(v1beta1.LocalConnectionKind))
+		Expect(provider.Spec.InstanceKind).To(Equal(v1beta1.LocalInstanceKind))
+		Expect(provider.Spec.CredentialFields).To(BeEmpty())
+		Expect(provider.Spec.ProvisioningParameters).To(HaveKey(v1beta1.ProvisioningDatabaseType))
+		for databaseType := range engines {
+			Expect(provider.Spec.ProvisioningParameters[v1beta1.ProvisioningDatabaseType].ConditionalData[0].Options).
+				To(ContainElement(HaveField("Value", databaseType)))
+		}
+	})
+})
+
+var _ = Describe("Instance and connection with the same name", func() {
+	It("should issue the credentials of both", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+		instance := newInstance("test-db", "test-ns", "test-inventory", nil)
+		instance.Status = v1beta1.DBaaSInstanceStatus{}
+		connection := &v1beta1.LocalConnection{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-db", Namespace: "test-ns"},
+			Spec: v1beta1.DBaaSConnectionSpec{
+				InventoryRef:       v1beta1.NamespacedName{Name: "test-inventory", Namespace: "test-ns"},
+				DatabaseServiceRef: &v1beta1.NamespacedName{Name: "test-db", Namespace: "test-ns"},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(instance, connection).Build()
+		ctx := context.Background()
+		req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)}
+
+		_, err := (&LocalInstanceReconciler{Client: c, Scheme: scheme}).Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(ctx, req.NamespacedName, instance)).To(Succeed())
+		instance.Status.Phase = v1beta1.InstancePhaseReady
+		Expect(c.Status().Update(ctx, instance)).To(Succeed())
+
+		_, err = (&LocalConnectionReconciler{Client: c, Scheme: scheme}).Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(ctx, req.NamespacedName, connection)).To(Succeed())
+		Expect(connection.Status.CredentialsRef).To(Equal(&corev1.LocalObjectReference{Name: "test-db-connection-credentials"}))
+
+		instanceSecret := &corev1.Secret{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "test-db-instance-credentials", Namespace: "test-ns"}, instanceSecret)).To(Succeed())
+		Expect(metav1.IsControlledBy(instanceSecret, instance)).To(BeTrue())
+		connectionSecret := &corev1.Secret{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "test-db-connection-credentials", Namespace: "test-ns"}, connectionSecret)).To(Succeed())
+		Expect(metav1.IsControlledBy(connectionSecret, connection)).To(BeTrue())
+		Expect(connectionSecret.Data).To(Equal(map[string][]byte{
+			usernameKey: instanceSecret.Data[usernameKey],
+			passwordKey: instanceSecret.Data[passwordKey],
+		}))
+	})
+})
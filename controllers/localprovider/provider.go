@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localprovider
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// EnvEnableLocalProvider enables the local provider when set to true
+const EnvEnableLocalProvider = "ENABLE_LOCAL_PROVIDER"
+
+// providerIcon is the catalog tile icon of the local provider
+const providerIcon = "PHN2ZyB4bWxucz0iaHR0cDovL3d3dy53My5vcmcvMjAwMC9zdmciIHZpZXdCb3g9IjAgMCAzMiAzMiI+PGVsbGlwc2UgY3g9IjE2IiBjeT0iNyIgcng9IjEyIiByeT0iNCIgZmlsbD0iIzA2NiIvPjxwYXRoIGQ9Ik00IDd2MThjMCAyLjIgNS40IDQgMTIgNHMxMi0xLjggMTItNFY3IiBmaWxsPSIjMDY2Ii8+PC9zdmc+"
+
+// getProvider returns the DBaaSProvider registering the local provider
+func getProvider() *v1beta1.DBaaSProvider {
+	return &v1beta1.DBaaSProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: v1beta1.LocalRegistration},
+	}
+}
+
+// mutateProvider sets the spec of the DBaaSProvider registering the local provider
+func mutateProvider(provider *v1beta1.DBaaSProvider) {
+	provider.Spec = v1beta1.DBaaSProviderSpec{
+		Provider: v1beta1.DatabaseProviderInfo{
+			Name:               "OpenShift Database Access / Local",
+			DisplayName:        "Local databases for development",
+			DisplayDescription: "PostgreSQL and MySQL databases running inside the cluster, for development and testing only.",
+			Icon: v1beta1.ProviderIcon{
+				Data:      providerIcon,
+				MediaType: "image/svg+xml",
+			},
+		},
+		GroupVersion:     v1beta1.GroupVersion.String(),
+		InventoryKind:    v1beta1.LocalInventoryKind,
+		ConnectionKind:   v1beta1.LocalConnectionKind,
+		InstanceKind:     v1beta1.LocalInstanceKind,
+		CredentialFields: []v1beta1.CredentialField{},
+		ProvisioningParameters: map[v1beta1.ProvisioningParameterType]v1beta1.ProvisioningParameter{
+			v1beta1.ProvisioningName: {
+				DisplayName: "Database name",
+				HelpText:    "The name of the database created in the instance. The name of the instance is used by default.",
+			},
+			v1beta1.ProvisioningDatabaseType: {
+				DisplayName: "Database type",
+				ConditionalData: []v1beta1.ConditionalProvisioningParameterData{{
+					Options: []v1beta1.Option{
+						{Value: "postgresql", DisplayValue: "PostgreSQL"},
+						{Value: "mysql", DisplayValue: "MySQL"},
+					},
+					DefaultValue: "postgresql",
+				}},
+			},
+			v1beta1.ProvisioningStorageGib: {
+				DisplayName: "Storage (GiB)",
+				HelpText:    "The size of the database volume.",
+				ConditionalData: []v1beta1.ConditionalProvisioningParameterData{{
+					DefaultValue: "1",
+				}},
+			},
+		},
+	}
+}
+
+// ProviderRegistration creates or updates the DBaaSProvider of the local provider when the manager starts
+type ProviderRegistration struct {
+	client.Client
+}
+
+// Start implements manager.Runnable
+func (r *ProviderRegistration) Start(ctx context.Context) error {
+	provider := getProvider()
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, provider, func() error {
+		mutateProvider(provider)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).Info("Local provider registered", "provider", provider.Name, "result", result)
+	return nil
+}
+
+// SetupWithManager sets up the controllers and the registration of the local provider with the Manager.
+func SetupWithManager(mgr ctrl.Manager) error {
+	if err := (&LocalInventoryReconciler{
+		Client: mgr.GetClient(),
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+	if err := (&LocalInstanceReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+	if err := (&LocalConnectionReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+	return mgr.Add(&ProviderRegistration{Client: mgr.GetClient()})
+}
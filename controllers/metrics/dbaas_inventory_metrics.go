@@ -25,6 +25,7 @@ const (
 	LabelErrorCdValueErrorUpdatingInventoryStatus         = "error_updating_inventory_status"
 	LabelErrorCdValueErrorDeletingInventory               = "error_deleting_inventory"
 	LabelErrorCdValueErrCheckingInventory                 = "error_checking_inventory"
+	LabelErrorCdValueErrorResolvingInventoryPolicy        = "error_resolving_inventory_policy"
 )
 
 // DBaaSInventoryStatusGauge defines a gauge for DBaaSInventoryStatus
@@ -18,6 +18,7 @@ const (
 	LabelErrorCdValueErrorResourceQuotaModified        = "error_resource_quota_modified"
 	LabelErrorCdValueErrUpdatingResourceQuota          = "error_updating_resource_quota"
 	LabelErrorCdValueErrorDeletingPolicy               = "error_deleting_dbaas_policy"
+	LabelErrorCdValueErrorListingPolicyInstances       = "error_listing_dbaas_policy_instances"
 )
 
 // SetPolicyMetrics set the Metrics for policy
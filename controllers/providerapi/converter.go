@@ -0,0 +1,105 @@
+package providerapi
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// Converter converts the specs of the DBaaS objects to the specs of the provider objects of a provider API version,
+// and the provider objects of the version to the v1beta1 provider objects
+type Converter interface {
+	// InventorySpec returns the spec of the provider inventory
+	InventorySpec(spec *v1beta1.DBaaSOperatorInventorySpec) (interface{}, error)
+	// NewInventory returns an empty provider inventory, the provider inventory object is parsed into it
+	NewInventory() interface{}
+	// ConvertInventory converts a provider inventory returned by NewInventory to v1beta1
+	ConvertInventory(providerInventory interface{}) *v1beta1.DBaaSProviderInventory
+
+	// ConnectionSpec returns the spec of the provider connection
+	ConnectionSpec(spec *v1beta1.DBaaSConnectionSpec) (interface{}, error)
+	// NewConnection returns an empty provider connection, the provider connection object is parsed into it
+	NewConnection() interface{}
+	// ConvertConnection converts a provider connection returned by NewConnection to v1beta1
+	ConvertConnection(providerConnection interface{}) *v1beta1.DBaaSProviderConnection
+
+	// InstanceSpec returns the spec of the provider instance
+	InstanceSpec(spec *v1beta1.DBaaSInstanceSpec) (interface{}, error)
+	// NewInstance returns an empty provider instance, the provider instance object is parsed into it
+	NewInstance() interface{}
+	// ConvertInstance converts a provider instance returned by NewInstance to v1beta1
+	ConvertInstance(providerInstance interface{}) *v1beta1.DBaaSProviderInstance
+
+	// BackupSpec returns the spec of the provider backup
+	BackupSpec(spec *v1beta1.DBaaSBackupSpec) (interface{}, error)
+	// NewBackup returns an empty provider backup, the provider backup object is parsed into it
+	NewBackup() interface{}
+	// ConvertBackup converts a provider backup returned by NewBackup to v1beta1
+	ConvertBackup(providerBackup interface{}) *v1beta1.DBaaSProviderBackup
+
+	// RestoreSpec returns the spec of the provider restore
+	RestoreSpec(spec *v1beta1.DBaaSRestoreSpec) (interface{}, error)
+	// NewRestore returns an empty provider restore, the provider restore object is parsed into it
+	NewRestore() interface{}
+	// ConvertRestore converts a provider restore returned by NewRestore to v1beta1
+	ConvertRestore(providerRestore interface{}) *v1beta1.DBaaSProviderRestore
+}
+
+var (
+	mutex sync.RWMutex
+	// converters are the converters of the supported provider API versions, by version of the DBaaS API group
+	converters = map[string]Converter{
+		v1alpha1.GroupVersion.Version: v1alpha1Converter{},
+		v1beta1.GroupVersion.Version:  v1beta1Converter{},
+	}
+	// specStatusVersions are the providers whose objects are served at a version of the DBaaS API group,
+	// but use the spec and status of another version
+	specStatusVersions = map[string]string{
+		v1beta1.RdsRegistration: v1beta1.GroupVersion.Version,
+	}
+)
+
+// Register registers the converter of a provider API version of the DBaaS API group
+func Register(version string, converter Converter) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	converters[version] = converter
+}
+
+// SupportedVersions returns the provider API versions with a registered converter, sorted
+func SupportedVersions() []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	versions := make([]string, 0, len(converters))
+	for version := range converters {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// GetSpecStatusVersion returns the version of the spec and status of the provider objects
+func GetSpecStatusVersion(provider *v1beta1.DBaaSProvider) schema.GroupVersion {
+	groupVersion := provider.GetDBaaSAPIGroupVersion()
+	if version, ok := specStatusVersions[provider.Name]; ok {
+		groupVersion.Version = version
+	}
+	return groupVersion
+}
+
+// ForProvider returns the converter of the spec and status version of the provider objects
+func ForProvider(provider *v1beta1.DBaaSProvider) (Converter, error) {
+	groupVersion := GetSpecStatusVersion(provider)
+	mutex.RLock()
+	defer mutex.RUnlock()
+	converter, ok := converters[groupVersion.Version]
+	if !ok || groupVersion.Group != v1beta1.GroupVersion.Group {
+		return nil, fmt.Errorf("provider API group version %s is not supported", groupVersion.String())
+	}
+	return converter, nil
+}
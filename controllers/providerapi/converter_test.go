@@ -0,0 +1,125 @@
+package providerapi
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+var _ = Describe("ForProvider", func() {
+	newProvider := func(name, groupVersion string) *v1beta1.DBaaSProvider {
+		return &v1beta1.DBaaSProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1beta1.DBaaSProviderSpec{GroupVersion: groupVersion},
+		}
+	}
+
+	It("should use the v1alpha1 converter when the provider does not set a version", func() {
+		converter, err := ForProvider(newProvider("test-provider", ""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(converter).To(Equal(v1alpha1Converter{}))
+	})
+
+	It("should use the v1beta1 spec and status for the RDS provider objects", func() {
+		provider := newProvider("rds-registration", v1alpha1.GroupVersion.String())
+		Expect(GetSpecStatusVersion(provider)).To(Equal(v1beta1.GroupVersion))
+		converter, err := ForProvider(provider)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(converter).To(Equal(v1beta1Converter{}))
+	})
+
+	It("should not support the versions without converter", func() {
+		_, err := ForProvider(newProvider("test-provider", "dbaas.redhat.com/v2"))
+		Expect(err).To(MatchError("provider API group version dbaas.redhat.com/v2 is not supported"))
+		_, err = ForProvider(newProvider("test-provider", "example.com/v1beta1"))
+		Expect(err).To(HaveOccurred())
+		Expect(SupportedVersions()).To(Equal([]string{"v1alpha1", "v1beta1"}))
+	})
+
+	It("should use the registered converters", func() {
+		Register("v2", v1beta1Converter{})
+		defer func() {
+			mutex.Lock()
+			delete(converters, "v2")
+			mutex.Unlock()
+		}()
+		converter, err := ForProvider(newProvider("test-provider", "dbaas.redhat.com/v2"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(converter).To(Equal(v1beta1Converter{}))
+		Expect(SupportedVersions()).To(Equal([]string{"v1alpha1", "v1beta1", "v2"}))
+	})
+})
+
+var _ = Describe("v1alpha1 converter", func() {
+	converter := v1alpha1Converter{}
+
+	It("should convert the inventory spec and status", func() {
+		spec, err := converter.InventorySpec(&v1beta1.DBaaSOperatorInventorySpec{
+			ProviderRef: v1beta1.NamespacedName{Name: "test-provider"},
+			DBaaSInventorySpec: v1beta1.DBaaSInventorySpec{
+				CredentialsRef: &v1beta1.LocalObjectReference{Name: "test-credentials"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(&v1alpha1.DBaaSOperatorInventorySpec{
+			ProviderRef: v1alpha1.NamespacedName{Name: "test-provider"},
+			DBaaSInventorySpec: v1alpha1.DBaaSInventorySpec{
+				CredentialsRef: &v1alpha1.LocalObjectReference{Name: "test-credentials"},
+			},
+		}))
+
+		providerInventory := converter.NewInventory().(*v1alpha1.DBaaSProviderInventory)
+		providerInventory.Status.Conditions = []metav1.Condition{{Type: "SpecSynced", Status: metav1.ConditionTrue}}
+		Expect(converter.ConvertInventory(providerInventory).Status.Conditions).To(Equal(providerInventory.Status.Conditions))
+	})
+
+	It("should convert the connection spec and status", func() {
+		spec, err := converter.ConnectionSpec(&v1beta1.DBaaSConnectionSpec{
+			InventoryRef:      v1beta1.NamespacedName{Name: "test-inventory", Namespace: "test-namespace"},
+			DatabaseServiceID: "test-instance-id",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(&v1alpha1.DBaaSConnectionSpec{
+			InventoryRef: v1alpha1.NamespacedName{Name: "test-inventory", Namespace: "test-namespace"},
+			InstanceID:   "test-instance-id",
+		}))
+
+		providerConnection := converter.NewConnection().(*v1alpha1.DBaaSProviderConnection)
+		providerConnection.Status.CredentialsRef = &corev1.LocalObjectReference{Name: "test-credentials"}
+		Expect(converter.ConvertConnection(providerConnection).Status.CredentialsRef.Name).To(Equal("test-credentials"))
+	})
+
+	It("should use the v1beta1 backup and restore", func() {
+		Expect(converter.NewBackup()).To(Equal(&v1beta1.DBaaSProviderBackup{}))
+		Expect(converter.NewRestore()).To(Equal(&v1beta1.DBaaSProviderRestore{}))
+	})
+})
+
+var _ = Describe("v1beta1 converter", func() {
+	converter := v1beta1Converter{}
+
+	It("should copy the specs", func() {
+		connectionSpec := &v1beta1.DBaaSConnectionSpec{DatabaseServiceRef: &v1beta1.NamespacedName{Name: "test-instance"}}
+		spec, err := converter.ConnectionSpec(connectionSpec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(connectionSpec))
+		Expect(spec).NotTo(BeIdenticalTo(connectionSpec))
+		Expect(spec.(*v1beta1.DBaaSConnectionSpec).DatabaseServiceRef).NotTo(BeIdenticalTo(connectionSpec.DatabaseServiceRef))
+
+		backupSpec := &v1beta1.DBaaSBackupSpec{DatabaseServiceID: "test-instance-id", Schedule: "0 0 * * *"}
+		spec, err = converter.BackupSpec(backupSpec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(backupSpec))
+		Expect(spec).NotTo(BeIdenticalTo(backupSpec))
+
+		restoreSpec := &v1beta1.DBaaSRestoreSpec{BackupID: "test-backup-id", DatabaseServiceID: "test-instance-id"}
+		spec, err = converter.RestoreSpec(restoreSpec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(restoreSpec))
+		Expect(spec).NotTo(BeIdenticalTo(restoreSpec))
+	})
+})
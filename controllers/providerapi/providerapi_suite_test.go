@@ -0,0 +1,13 @@
+package providerapi
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestProviderAPI(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Provider API Suite")
+}
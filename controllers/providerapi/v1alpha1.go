@@ -0,0 +1,64 @@
+package providerapi
+
+import (
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// v1alpha1Converter is the converter of the v1alpha1 provider API.
+// The v1alpha1 API has no backup and restore, the provider backups and restores use the v1beta1 specs and statuses.
+type v1alpha1Converter struct {
+	v1beta1Converter
+}
+
+var _ Converter = v1alpha1Converter{}
+
+func (v1alpha1Converter) InventorySpec(spec *v1beta1.DBaaSOperatorInventorySpec) (interface{}, error) {
+	providerSpec := &v1alpha1.DBaaSOperatorInventorySpec{}
+	providerSpec.ConvertFrom(spec)
+	return providerSpec, nil
+}
+
+func (v1alpha1Converter) NewInventory() interface{} {
+	return &v1alpha1.DBaaSProviderInventory{}
+}
+
+func (v1alpha1Converter) ConvertInventory(providerInventory interface{}) *v1beta1.DBaaSProviderInventory {
+	providerInventoryV1beta1 := &v1beta1.DBaaSProviderInventory{}
+	providerInventory.(*v1alpha1.DBaaSProviderInventory).Status.ConvertTo(&providerInventoryV1beta1.Status)
+	return providerInventoryV1beta1
+}
+
+func (v1alpha1Converter) ConnectionSpec(spec *v1beta1.DBaaSConnectionSpec) (interface{}, error) {
+	providerSpec := &v1alpha1.DBaaSConnectionSpec{}
+	providerSpec.ConvertFrom(spec)
+	return providerSpec, nil
+}
+
+func (v1alpha1Converter) NewConnection() interface{} {
+	return &v1alpha1.DBaaSProviderConnection{}
+}
+
+func (v1alpha1Converter) ConvertConnection(providerConnection interface{}) *v1beta1.DBaaSProviderConnection {
+	providerConnectionV1beta1 := &v1beta1.DBaaSProviderConnection{}
+	providerConnection.(*v1alpha1.DBaaSProviderConnection).Status.ConvertTo(&providerConnectionV1beta1.Status)
+	return providerConnectionV1beta1
+}
+
+func (v1alpha1Converter) InstanceSpec(spec *v1beta1.DBaaSInstanceSpec) (interface{}, error) {
+	providerSpec := &v1alpha1.DBaaSInstanceSpec{}
+	if err := providerSpec.ConvertFrom(spec); err != nil {
+		return nil, err
+	}
+	return providerSpec, nil
+}
+
+func (v1alpha1Converter) NewInstance() interface{} {
+	return &v1alpha1.DBaaSProviderInstance{}
+}
+
+func (v1alpha1Converter) ConvertInstance(providerInstance interface{}) *v1beta1.DBaaSProviderInstance {
+	providerInstanceV1beta1 := &v1beta1.DBaaSProviderInstance{}
+	providerInstance.(*v1alpha1.DBaaSProviderInstance).Status.ConvertTo(&providerInstanceV1beta1.Status)
+	return providerInstanceV1beta1
+}
@@ -0,0 +1,70 @@
+package providerapi
+
+import (
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// v1beta1Converter is the converter of the v1beta1 provider API, the provider objects use the v1beta1 specs and statuses
+type v1beta1Converter struct{}
+
+var _ Converter = v1beta1Converter{}
+
+func (v1beta1Converter) InventorySpec(spec *v1beta1.DBaaSOperatorInventorySpec) (interface{}, error) {
+	return spec.DeepCopy(), nil
+}
+
+func (v1beta1Converter) NewInventory() interface{} {
+	return &v1beta1.DBaaSProviderInventory{}
+}
+
+func (v1beta1Converter) ConvertInventory(providerInventory interface{}) *v1beta1.DBaaSProviderInventory {
+	return providerInventory.(*v1beta1.DBaaSProviderInventory)
+}
+
+func (v1beta1Converter) ConnectionSpec(spec *v1beta1.DBaaSConnectionSpec) (interface{}, error) {
+	return spec.DeepCopy(), nil
+}
+
+func (v1beta1Converter) NewConnection() interface{} {
+	return &v1beta1.DBaaSProviderConnection{}
+}
+
+func (v1beta1Converter) ConvertConnection(providerConnection interface{}) *v1beta1.DBaaSProviderConnection {
+	return providerConnection.(*v1beta1.DBaaSProviderConnection)
+}
+
+func (v1beta1Converter) InstanceSpec(spec *v1beta1.DBaaSInstanceSpec) (interface{}, error) {
+	return spec.DeepCopy(), nil
+}
+
+func (v1beta1Converter) NewInstance() interface{} {
+	return &v1beta1.DBaaSProviderInstance{}
+}
+
+func (v1beta1Converter) ConvertInstance(providerInstance interface{}) *v1beta1.DBaaSProviderInstance {
+	return providerInstance.(*v1beta1.DBaaSProviderInstance)
+}
+
+func (v1beta1Converter) BackupSpec(spec *v1beta1.DBaaSBackupSpec) (interface{}, error) {
+	return spec.DeepCopy(), nil
+}
+
+func (v1beta1Converter) NewBackup() interface{} {
+	return &v1beta1.DBaaSProviderBackup{}
+}
+
+func (v1beta1Converter) ConvertBackup(providerBackup interface{}) *v1beta1.DBaaSProviderBackup {
+	return providerBackup.(*v1beta1.DBaaSProviderBackup)
+}
+
+func (v1beta1Converter) RestoreSpec(spec *v1beta1.DBaaSRestoreSpec) (interface{}, error) {
+	return spec.DeepCopy(), nil
+}
+
+func (v1beta1Converter) NewRestore() interface{} {
+	return &v1beta1.DBaaSProviderRestore{}
+}
+
+func (v1beta1Converter) ConvertRestore(providerRestore interface{}) *v1beta1.DBaaSProviderRestore {
+	return providerRestore.(*v1beta1.DBaaSProviderRestore)
+}
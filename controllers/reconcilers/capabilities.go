@@ -0,0 +1,116 @@
+package reconcilers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	dbaasv1beta1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// Capabilities is the set of capabilities served by the cluster
+type Capabilities map[dbaasv1beta1.ClusterCapability]bool
+
+// capabilityKinds are the kinds that the cluster must serve for each capability
+var capabilityKinds = map[dbaasv1beta1.ClusterCapability][]schema.GroupVersionKind{
+	dbaasv1beta1.CapabilityOLM: {
+		{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "Subscription"},
+		{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "CatalogSource"},
+		{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "ClusterServiceVersion"},
+		{Group: "operators.coreos.com", Version: "v1", Kind: "OperatorGroup"},
+	},
+	dbaasv1beta1.CapabilityConsolePlugins: {
+		{Group: "console.openshift.io", Version: "v1alpha1", Kind: "ConsolePlugin"},
+		{Group: "operator.openshift.io", Version: "v1", Kind: "Console"},
+	},
+	dbaasv1beta1.CapabilityConsoleQuickStarts: {
+		{Group: "console.openshift.io", Version: "v1", Kind: "ConsoleQuickStart"},
+	},
+	dbaasv1beta1.CapabilityOpenShiftConfig: {
+		{Group: "config.openshift.io", Version: "v1", Kind: "ClusterVersion"},
+		{Group: "config.openshift.io", Version: "v1", Kind: "Console"},
+		{Group: "config.openshift.io", Version: "v1", Kind: "Infrastructure"},
+	},
+}
+
+// DetectCapabilities returns the capabilities of the cluster, a capability is served when the cluster serves all its kinds
+func DetectCapabilities(mapper meta.RESTMapper) (Capabilities, error) {
+	capabilities := make(Capabilities, len(capabilityKinds))
+	for capability, kinds := range capabilityKinds {
+		served := true
+		for _, gvk := range kinds {
+			if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+				if meta.IsNoMatchError(err) {
+					served = false
+					break
+				}
+				return nil, fmt.Errorf("error detecting the %s capability: %w", capability, err)
+			}
+		}
+		capabilities[capability] = served
+	}
+	return capabilities, nil
+}
+
+// Has returns true if the cluster serves the capability
+func (c Capabilities) Has(capability dbaasv1beta1.ClusterCapability) bool {
+	return c[capability]
+}
+
+// List returns the capabilities served by the cluster, sorted by name
+func (c Capabilities) List() []dbaasv1beta1.ClusterCapability {
+	var list []dbaasv1beta1.ClusterCapability
+	for capability, served := range c {
+		if served {
+			list = append(list, capability)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i] < list[j]
+	})
+	return list
+}
+
+// UseManifests returns true if the manifests of an operator are applied instead of subscribing to the operator
+func UseManifests(config dbaasv1beta1.PlatformConfig, capabilities Capabilities) bool {
+	return config.Type == dbaasv1beta1.TypeOperator && len(config.Manifests) > 0 && !capabilities.Has(dbaasv1beta1.CapabilityOLM)
+}
+
+// GetRequiredCapabilities returns the capabilities required to install a platform
+func GetRequiredCapabilities(config dbaasv1beta1.PlatformConfig, capabilities Capabilities) []dbaasv1beta1.ClusterCapability {
+	switch config.Type {
+	case dbaasv1beta1.TypeOperator:
+		if UseManifests(config, capabilities) {
+			return nil
+		}
+		return []dbaasv1beta1.ClusterCapability{dbaasv1beta1.CapabilityOLM}
+	case dbaasv1beta1.TypeObservability:
+		return []dbaasv1beta1.ClusterCapability{dbaasv1beta1.CapabilityOLM}
+	case dbaasv1beta1.TypeConsolePlugin:
+		return []dbaasv1beta1.ClusterCapability{dbaasv1beta1.CapabilityConsolePlugins}
+	case dbaasv1beta1.TypeQuickStart:
+		return []dbaasv1beta1.ClusterCapability{dbaasv1beta1.CapabilityConsoleQuickStarts}
+	}
+	return nil
+}
+
+// GetSkipReason returns why a platform is skipped on the cluster, or an empty string if the platform can be installed
+func GetSkipReason(config dbaasv1beta1.PlatformConfig, capabilities Capabilities) string {
+	var missing []string
+	for _, capability := range GetRequiredCapabilities(config, capabilities) {
+		if !capabilities.Has(capability) {
+			missing = append(missing, string(capability))
+		}
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	reason := fmt.Sprintf("missing cluster capabilities %s", strings.Join(missing, ", "))
+	if config.Type == dbaasv1beta1.TypeOperator {
+		reason += ", set the manifests of the operator to install it without Operator Lifecycle Manager"
+	}
+	return reason
+}
@@ -0,0 +1,58 @@
+package reconcilers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	dbaasv1beta1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+var _ = Describe("DetectCapabilities", func() {
+	It("should only detect the capabilities whose kinds are all served", func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		for _, gvk := range capabilityKinds[dbaasv1beta1.CapabilityOLM] {
+			mapper.Add(gvk, meta.RESTScopeNamespace)
+		}
+		mapper.Add(schema.GroupVersionKind{Group: "console.openshift.io", Version: "v1alpha1", Kind: "ConsolePlugin"}, meta.RESTScopeRoot)
+
+		capabilities, err := DetectCapabilities(mapper)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capabilities.List()).To(Equal([]dbaasv1beta1.ClusterCapability{dbaasv1beta1.CapabilityOLM}))
+	})
+})
+
+var _ = Describe("GetSkipReason", func() {
+	kubernetes := Capabilities{}
+	openShift := Capabilities{
+		dbaasv1beta1.CapabilityOLM:                true,
+		dbaasv1beta1.CapabilityConsolePlugins:     true,
+		dbaasv1beta1.CapabilityConsoleQuickStarts: true,
+		dbaasv1beta1.CapabilityOpenShiftConfig:    true,
+	}
+
+	It("should install all the built-in platforms on OpenShift", func() {
+		for _, config := range InstallationPlatforms {
+			Expect(GetSkipReason(config, openShift)).To(BeEmpty())
+		}
+	})
+
+	It("should skip all the built-in platforms on plain Kubernetes", func() {
+		for _, config := range InstallationPlatforms {
+			Expect(GetSkipReason(config, kubernetes)).NotTo(BeEmpty())
+		}
+		Expect(GetSkipReason(InstallationPlatforms[dbaasv1beta1.DBaaSDynamicPluginInstallation], kubernetes)).
+			To(Equal("missing cluster capabilities ConsolePlugins"))
+	})
+
+	It("should install the operators with manifests on plain Kubernetes", func() {
+		platforms, err := GetInstallationPlatforms([]dbaasv1beta1.PlatformSpec{
+			{Name: dbaasv1beta1.RDSProviderInstallation, Manifests: "rds-provider-manifests"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(UseManifests(platforms[dbaasv1beta1.RDSProviderInstallation], kubernetes)).To(BeTrue())
+		Expect(GetSkipReason(platforms[dbaasv1beta1.RDSProviderInstallation], kubernetes)).To(BeEmpty())
+		Expect(UseManifests(platforms[dbaasv1beta1.RDSProviderInstallation], openShift)).To(BeFalse())
+	})
+})
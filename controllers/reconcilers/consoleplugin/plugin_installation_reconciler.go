@@ -6,6 +6,7 @@ import (
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/util"
 	"github.com/go-logr/logr"
 	consolev1alpha1 "github.com/openshift/api/console/v1alpha1"
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -183,7 +184,7 @@ func (r *reconciler) reconcileDeployment(ctx context.Context, cr *v1beta1.DBaaSP
 						Protocol:      v1.ProtocolTCP,
 					},
 				},
-				ImagePullPolicy: v1.PullAlways,
+				ImagePullPolicy: imagePullPolicy(r.config.Image),
 				Args: []string{
 					"--ssl",
 					"--cert=/var/serving-cert/tls.crt",
@@ -365,3 +366,11 @@ func (r *reconciler) removePlugin(plugins []string) []string {
 
 	return plugins
 }
+
+// imagePullPolicy does not pull the images pinned by digest again, so they are not pulled from the registry when they are present on the node
+func imagePullPolicy(image string) v1.PullPolicy {
+	if util.IsDigestPinned(image) {
+		return v1.PullIfNotPresent
+	}
+	return v1.PullAlways
+}
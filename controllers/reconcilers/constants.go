@@ -67,6 +67,8 @@ var InstallationPlatforms = map[dbaasv1beta1.PlatformName]dbaasv1beta1.PlatformC
 		Image:       fetchEnvValue(dbaasDynamicPluginImg),
 		DisplayName: dbaasDynamicPluginDisplayName,
 		Type:        dbaasv1beta1.TypeConsolePlugin,
+		// the dashboards of the plugin show the metrics collected by the observability stack
+		DependsOn: []dbaasv1beta1.PlatformName{dbaasv1beta1.ObservabilityInstallation},
 	},
 	dbaasv1beta1.CrunchyBridgeInstallation: {
 		Name:           crunchyBridgeName,
@@ -89,8 +91,9 @@ var InstallationPlatforms = map[dbaasv1beta1.PlatformName]dbaasv1beta1.PlatformC
 		Type:           dbaasv1beta1.TypeOperator,
 	},
 	dbaasv1beta1.DBaaSQuickStartInstallation: {
-		Type: dbaasv1beta1.TypeQuickStart,
-		CSV:  DBaaSQuickStartVersion,
+		Type:      dbaasv1beta1.TypeQuickStart,
+		CSV:       DBaaSQuickStartVersion,
+		DependsOn: []dbaasv1beta1.PlatformName{dbaasv1beta1.DBaaSDynamicPluginInstallation},
 	},
 	dbaasv1beta1.RDSProviderInstallation: {
 		Name:           rdsProviderName,
@@ -109,6 +112,93 @@ var InstallationPlatforms = map[dbaasv1beta1.PlatformName]dbaasv1beta1.PlatformC
 	},
 }
 
+// GetInstallationPlatforms returns the platforms to install, with the settings of the DBaaSPlatform applied to the built-in platforms
+func GetInstallationPlatforms(specs []dbaasv1beta1.PlatformSpec) (map[dbaasv1beta1.PlatformName]dbaasv1beta1.PlatformConfig, error) {
+	platforms := make(map[dbaasv1beta1.PlatformName]dbaasv1beta1.PlatformConfig, len(InstallationPlatforms)+len(specs))
+	for name, config := range InstallationPlatforms {
+		platforms[name] = config
+	}
+	for _, spec := range specs {
+		if spec.Enabled != nil && !*spec.Enabled {
+			delete(platforms, spec.Name)
+			continue
+		}
+		config, builtIn := platforms[spec.Name]
+		if !builtIn {
+			if len(spec.PackageName) == 0 && len(spec.Manifests) == 0 {
+				return nil, fmt.Errorf("platform %s is not a built-in platform, packageName or manifests is required", spec.Name)
+			}
+			config = GetPlatformConfig(spec.Name)
+		}
+		platforms[spec.Name] = applyPlatformSpec(config, spec)
+	}
+	return platforms, nil
+}
+
+// GetCatalogNamespace returns the namespace of the catalog sources set in the DBaaSPlatform, or the default one
+func GetCatalogNamespace(cr *dbaasv1beta1.DBaaSPlatform) string {
+	if len(cr.Spec.CatalogNamespace) > 0 {
+		return cr.Spec.CatalogNamespace
+	}
+	return CatalogNamespace
+}
+
+// GetPlatformConfig returns the default settings of a platform, additional platforms are provider operators
+func GetPlatformConfig(name dbaasv1beta1.PlatformName) dbaasv1beta1.PlatformConfig {
+	if config, builtIn := InstallationPlatforms[name]; builtIn {
+		return config
+	}
+	return dbaasv1beta1.PlatformConfig{
+		Name:        string(name),
+		DisplayName: string(name),
+		Type:        dbaasv1beta1.TypeOperator,
+	}
+}
+
+// GetRemovedPlatformConfig returns the settings of a platform that is no longer installed, with the settings of the DBaaSPlatform applied if it is disabled
+func GetRemovedPlatformConfig(name dbaasv1beta1.PlatformName, specs []dbaasv1beta1.PlatformSpec) dbaasv1beta1.PlatformConfig {
+	config := GetPlatformConfig(name)
+	for _, spec := range specs {
+		if spec.Name == name {
+			return applyPlatformSpec(config, spec)
+		}
+	}
+	return config
+}
+
+// applyPlatformSpec overrides the settings of a platform with the ones set in the DBaaSPlatform
+func applyPlatformSpec(config dbaasv1beta1.PlatformConfig, spec dbaasv1beta1.PlatformSpec) dbaasv1beta1.PlatformConfig {
+	if len(spec.PackageName) > 0 {
+		config.PackageName = spec.PackageName
+	}
+	if len(spec.Channel) > 0 {
+		config.Channel = spec.Channel
+	}
+	if len(spec.CSV) > 0 {
+		config.CSV = spec.CSV
+	}
+	if len(spec.CatalogImage) > 0 {
+		config.Image = spec.CatalogImage
+	}
+	if len(spec.DeploymentName) > 0 {
+		config.DeploymentName = spec.DeploymentName
+	}
+	if len(spec.DisplayName) > 0 {
+		config.DisplayName = spec.DisplayName
+	}
+	if spec.DependsOn != nil {
+		config.DependsOn = spec.DependsOn
+	}
+	if len(spec.InstallPlanApproval) > 0 {
+		config.Approval = spec.InstallPlanApproval
+	}
+	config.TargetCSV = spec.TargetCSV
+	if len(spec.Manifests) > 0 {
+		config.Manifests = spec.Manifests
+	}
+	return config
+}
+
 // GetObservabilityConfig return observatorium configuration
 func GetObservabilityConfig() dbaasv1beta1.ObservabilityConfig {
 	return dbaasv1beta1.ObservabilityConfig{
@@ -7,6 +7,9 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+
+	dbaasv1beta1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
 )
 
 var _ = Describe("FetchImageAndVersion", func() {
@@ -31,6 +34,62 @@ var _ = Describe("FetchImageAndVersion", func() {
 	})
 })
 
+var _ = Describe("GetInstallationPlatforms", func() {
+	It("should install the built-in platforms by default", func() {
+		platforms, err := GetInstallationPlatforms(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(platforms).To(Equal(InstallationPlatforms))
+	})
+
+	It("should apply the platform settings", func() {
+		platforms, err := GetInstallationPlatforms([]dbaasv1beta1.PlatformSpec{
+			{
+				Name:    dbaasv1beta1.CockroachDBInstallation,
+				Enabled: pointer.Bool(false),
+			},
+			{
+				Name:         dbaasv1beta1.CrunchyBridgeInstallation,
+				Channel:      "stable",
+				CSV:          "crunchy-bridge-operator.v1.0.0",
+				CatalogImage: "registry.example.com/crunchy-bridge-catalog:v1.0.0",
+
+				InstallPlanApproval: dbaasv1beta1.InstallPlanApprovalManual,
+				TargetCSV:           "crunchy-bridge-operator.v1.1.0",
+			},
+			{
+				Name:        "mongodb-atlas",
+				PackageName: "mongodb-atlas-kubernetes",
+				Channel:     "stable",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(platforms).NotTo(HaveKey(dbaasv1beta1.CockroachDBInstallation))
+		Expect(platforms).To(HaveKey(dbaasv1beta1.RDSProviderInstallation))
+
+		crunchyBridge := platforms[dbaasv1beta1.CrunchyBridgeInstallation]
+		Expect(crunchyBridge.Channel).To(Equal("stable"))
+		Expect(crunchyBridge.CSV).To(Equal("crunchy-bridge-operator.v1.0.0"))
+		Expect(crunchyBridge.Image).To(Equal("registry.example.com/crunchy-bridge-catalog:v1.0.0"))
+		Expect(crunchyBridge.PackageName).To(Equal(crunchyBridgePkg))
+		Expect(crunchyBridge.DeploymentName).To(Equal(crunchyBridgeDeployment))
+		Expect(crunchyBridge.Approval).To(Equal(dbaasv1beta1.InstallPlanApprovalManual))
+		Expect(crunchyBridge.TargetCSV).To(Equal("crunchy-bridge-operator.v1.1.0"))
+
+		Expect(platforms["mongodb-atlas"]).To(Equal(dbaasv1beta1.PlatformConfig{
+			Name:        "mongodb-atlas",
+			PackageName: "mongodb-atlas-kubernetes",
+			Channel:     "stable",
+			DisplayName: "mongodb-atlas",
+			Type:        dbaasv1beta1.TypeOperator,
+		}))
+	})
+
+	It("should require the package name of additional provider operators", func() {
+		_, err := GetInstallationPlatforms([]dbaasv1beta1.PlatformSpec{{Name: "mongodb-atlas"}})
+		Expect(err).To(MatchError("platform mongodb-atlas is not a built-in platform, packageName or manifests is required"))
+	})
+})
+
 func Test(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "FetchEnvValue Suite")
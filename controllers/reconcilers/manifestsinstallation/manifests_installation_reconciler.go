@@ -0,0 +1,233 @@
+package manifestsinstallation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers"
+)
+
+const (
+	// fieldOwner is the field manager of the applied manifests
+	fieldOwner = "dbaas-operator"
+	// platformLabel is set on the applied objects, with the platform name as value
+	platformLabel = "dbaas.redhat.com/platform"
+)
+
+// kindOrder are the kinds applied before the other objects of the manifests
+var kindOrder = map[string]int{
+	"CustomResourceDefinition": 0,
+	"ServiceAccount":           1,
+	"Role":                     2,
+	"RoleBinding":              3,
+}
+
+// allowedKinds are the kinds of the objects that can be applied from the manifests, and whether they are cluster-scoped.
+// Only the custom resource definitions are cluster-scoped: the cluster-wide RBAC objects are not allowed, as they would grant
+// the privileges of the DBaaS Operator to the users allowed to edit the manifests.
+var allowedKinds = map[schema.GroupKind]bool{
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: true,
+	{Kind: "ServiceAccount"}: false,
+	{Kind: "ConfigMap"}:      false,
+	{Kind: "Secret"}:         false,
+	{Kind: "Service"}:        false,
+	{Group: "rbac.authorization.k8s.io", Kind: "Role"}:        false,
+	{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}: false,
+	{Group: "apps", Kind: "Deployment"}:                       false,
+}
+
+type reconciler struct {
+	client client.Client
+	logger logr.Logger
+	config v1beta1.PlatformConfig
+}
+
+// NewReconciler returns a reconciler installing a provider operator from its manifests, on clusters without Operator Lifecycle Manager.
+// The manifests can only contain the allowed kinds, in the namespace of the DBaaS Operator.
+func NewReconciler(client client.Client, logger logr.Logger, config v1beta1.PlatformConfig) reconcilers.PlatformReconciler {
+	return &reconciler{
+		client: client,
+		logger: logger,
+		config: config,
+	}
+}
+
+// Reconcile applies the manifests of the operator, and waits for its deployments to be ready
+func (r *reconciler) Reconcile(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1beta1.PlatformInstlnStatus, error) {
+	objects, err := r.getManifests(ctx, cr)
+	if err != nil {
+		return v1beta1.ResultFailed, err
+	}
+
+	for _, obj := range objects {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[platformLabel] = r.config.Name
+		obj.SetLabels(labels)
+		// the fields managed by other field managers are not taken over
+		if err := r.client.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldOwner)); err != nil {
+			return v1beta1.ResultFailed, fmt.Errorf("error applying %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	return r.waitForDeployments(ctx, objects)
+}
+
+// Cleanup deletes the objects of the manifests, in the reverse order
+func (r *reconciler) Cleanup(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1beta1.PlatformInstlnStatus, error) {
+	objects, err := r.getManifests(ctx, cr)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.logger.Info("Manifests not found, the objects of the platform are not removed", "platform", r.config.Name)
+			return v1beta1.ResultSuccess, nil
+		}
+		return v1beta1.ResultFailed, err
+	}
+
+	for i := len(objects) - 1; i >= 0; i-- {
+		// the custom resource definitions may be shared with other installations
+		if objects[i].GetKind() == "CustomResourceDefinition" {
+			continue
+		}
+		if err := r.client.Delete(ctx, objects[i]); err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return v1beta1.ResultFailed, err
+		}
+	}
+	return v1beta1.ResultSuccess, nil
+}
+
+// getManifests returns the objects of the manifests, with the namespace of the DBaaS Operator set on the namespaced objects
+func (r *reconciler) getManifests(ctx context.Context, cr *v1beta1.DBaaSPlatform) ([]*unstructured.Unstructured, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: r.config.Manifests, Namespace: cr.Namespace}, cm); err != nil {
+		return nil, err
+	}
+	objects, err := decodeManifests(cm.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifests in ConfigMap %s: %w", cm.Name, err)
+	}
+	for _, obj := range objects {
+		if err := checkManifestObject(obj, cr.Namespace); err != nil {
+			return nil, fmt.Errorf("invalid manifests in ConfigMap %s: %w", cm.Name, err)
+		}
+	}
+	return objects, nil
+}
+
+// checkManifestObject checks that the object kind is allowed, and that a namespaced object is in the namespace of the DBaaS Operator.
+// The namespace is set on the namespaced objects without namespace.
+func checkManifestObject(obj *unstructured.Unstructured, namespace string) error {
+	gvk := obj.GroupVersionKind()
+	clusterScoped, ok := allowedKinds[gvk.GroupKind()]
+	if !ok {
+		return fmt.Errorf("%s %s: kind is not allowed", gvk.Kind, obj.GetName())
+	}
+	if clusterScoped {
+		if len(obj.GetNamespace()) > 0 {
+			return fmt.Errorf("%s %s: kind is cluster-scoped", gvk.Kind, obj.GetName())
+		}
+		return nil
+	}
+	if len(obj.GetNamespace()) == 0 {
+		obj.SetNamespace(namespace)
+	} else if obj.GetNamespace() != namespace {
+		return fmt.Errorf("%s %s: namespace %s is not the namespace of the DBaaS Operator", gvk.Kind, obj.GetName(), obj.GetNamespace())
+	}
+	if gvk.Kind == "RoleBinding" {
+		// a role binding to a cluster role could grant any privileges of the DBaaS Operator in its namespace
+		if roleKind, _, _ := unstructured.NestedString(obj.Object, "roleRef", "kind"); roleKind != "Role" {
+			return fmt.Errorf("%s %s: only the roles of the manifests can be bound", gvk.Kind, obj.GetName())
+		}
+	}
+	return nil
+}
+
+// waitForDeployments returns success when all the deployments of the manifests have rolled out, with all their desired replicas available
+func (r *reconciler) waitForDeployments(ctx context.Context, objects []*unstructured.Unstructured) (v1beta1.PlatformInstlnStatus, error) {
+	for _, obj := range objects {
+		if obj.GetKind() != "Deployment" {
+			continue
+		}
+		deployment := &apiv1.Deployment{}
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(obj), deployment); err != nil {
+			if errors.IsNotFound(err) {
+				return v1beta1.ResultInProgress, nil
+			}
+			return v1beta1.ResultFailed, err
+		}
+		if !deploymentAvailable(deployment) {
+			return v1beta1.ResultInProgress, nil
+		}
+	}
+	return v1beta1.ResultSuccess, nil
+}
+
+// deploymentAvailable returns true if the latest spec of the deployment is rolled out, and its desired replicas are available
+func deploymentAvailable(deployment *apiv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas >= replicas &&
+		deployment.Status.AvailableReplicas >= replicas
+}
+
+// decodeManifests decodes the YAML documents of the ConfigMap entries, sorted by entry name.
+// The custom resource definitions and RBAC objects are ordered first.
+func decodeManifests(data map[string]string) ([]*unstructured.Unstructured, error) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var objects []*unstructured.Unstructured
+	for _, key := range keys {
+		decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(data[key]), 4096)
+		for {
+			obj := &unstructured.Unstructured{}
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return nil, fmt.Errorf("entry %s: %w", key, err)
+			}
+			if len(obj.Object) == 0 {
+				continue
+			}
+			if len(obj.GetAPIVersion()) == 0 || len(obj.GetKind()) == 0 || len(obj.GetName()) == 0 {
+				return nil, fmt.Errorf("entry %s: apiVersion, kind and metadata.name are required", key)
+			}
+			objects = append(objects, obj)
+		}
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		return kindRank(objects[i].GetKind()) < kindRank(objects[j].GetKind())
+	})
+	return objects, nil
+}
+
+func kindRank(kind string) int {
+	if rank, ok := kindOrder[kind]; ok {
+		return rank
+	}
+	return len(kindOrder)
+}
@@ -0,0 +1,138 @@
+package manifestsinstallation
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	apiv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("decodeManifests", func() {
+	It("should decode the documents of all entries, with the cluster setup first", func() {
+		objects, err := decodeManifests(map[string]string{
+			"b-operator.yaml": `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: provider-operator
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: provider-operator
+---
+`,
+			"a-crds.yaml": `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: providerinventories.example.com
+`,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		var kinds []string
+		for _, obj := range objects {
+			kinds = append(kinds, obj.GetKind())
+		}
+		Expect(kinds).To(Equal([]string{"CustomResourceDefinition", "ServiceAccount", "Deployment"}))
+	})
+
+	It("should reject incomplete objects", func() {
+		_, err := decodeManifests(map[string]string{
+			"operator.yaml": `
+apiVersion: v1
+kind: ServiceAccount
+`,
+		})
+		Expect(err).To(MatchError("entry operator.yaml: apiVersion, kind and metadata.name are required"))
+	})
+})
+
+var _ = Describe("checkManifestObject", func() {
+	decode := func(manifest string) *unstructured.Unstructured {
+		objects, err := decodeManifests(map[string]string{"manifest.yaml": manifest})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(1))
+		return objects[0]
+	}
+
+	It("should set the namespace of the DBaaS Operator on the namespaced objects", func() {
+		obj := decode(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: provider-operator
+`)
+		Expect(checkManifestObject(obj, "dbaas-operator")).To(Succeed())
+		Expect(obj.GetNamespace()).To(Equal("dbaas-operator"))
+
+		crd := decode(`
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: providerinventories.example.com
+`)
+		Expect(checkManifestObject(crd, "dbaas-operator")).To(Succeed())
+		Expect(crd.GetNamespace()).To(BeEmpty())
+	})
+
+	It("should reject the cluster-wide RBAC objects", func() {
+		obj := decode(`
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: provider-operator
+`)
+		Expect(checkManifestObject(obj, "dbaas-operator")).To(MatchError("ClusterRoleBinding provider-operator: kind is not allowed"))
+	})
+
+	It("should reject the role bindings to cluster roles", func() {
+		obj := decode(`
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: provider-operator
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: cluster-admin
+`)
+		Expect(checkManifestObject(obj, "dbaas-operator")).To(MatchError("RoleBinding provider-operator: only the roles of the manifests can be bound"))
+	})
+
+	It("should reject the objects outside the namespace of the DBaaS Operator", func() {
+		obj := decode(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: provider-secret
+  namespace: kube-system
+`)
+		Expect(checkManifestObject(obj, "dbaas-operator")).To(MatchError("Secret provider-secret: namespace kube-system is not the namespace of the DBaaS Operator"))
+	})
+})
+
+var _ = Describe("deploymentAvailable", func() {
+	It("should wait for all the desired replicas of the latest spec", func() {
+		deployment := &apiv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       apiv1.DeploymentSpec{Replicas: pointer.Int32(2)},
+			Status: apiv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				UpdatedReplicas:    2,
+				ReadyReplicas:      1,
+				AvailableReplicas:  1,
+			},
+		}
+		Expect(deploymentAvailable(deployment)).To(BeFalse())
+
+		deployment.Status.AvailableReplicas = 2
+		Expect(deploymentAvailable(deployment)).To(BeTrue())
+
+		deployment.Generation = 3
+		Expect(deploymentAvailable(deployment)).To(BeFalse())
+	})
+})
@@ -0,0 +1,76 @@
+package reconcilers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	dbaasv1beta1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// GetInstallationOrder returns the platforms grouped in installation levels, each platform only depends on platforms in previous levels.
+// Dependencies on platforms that are not installed are ignored, and the platforms are sorted by name in each level.
+func GetInstallationOrder(platforms map[dbaasv1beta1.PlatformName]dbaasv1beta1.PlatformConfig) ([][]dbaasv1beta1.PlatformName, error) {
+	pending := make(map[dbaasv1beta1.PlatformName]int, len(platforms))
+	dependents := map[dbaasv1beta1.PlatformName][]dbaasv1beta1.PlatformName{}
+	for name, config := range platforms {
+		pending[name] = 0
+		for _, dependency := range GetInstalledDependencies(config, platforms) {
+			pending[name]++
+			dependents[dependency] = append(dependents[dependency], name)
+		}
+	}
+
+	var levels [][]dbaasv1beta1.PlatformName
+	var level []dbaasv1beta1.PlatformName
+	for name, count := range pending {
+		if count == 0 {
+			level = append(level, name)
+		}
+	}
+	ordered := 0
+	for len(level) > 0 {
+		sortPlatformNames(level)
+		levels = append(levels, level)
+		ordered += len(level)
+		var next []dbaasv1beta1.PlatformName
+		for _, name := range level {
+			for _, dependent := range dependents[name] {
+				pending[dependent]--
+				if pending[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		level = next
+	}
+
+	if ordered < len(platforms) {
+		var cycle []string
+		for name, count := range pending {
+			if count > 0 {
+				cycle = append(cycle, string(name))
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("circular dependency between platforms %s", strings.Join(cycle, ", "))
+	}
+	return levels, nil
+}
+
+// GetInstalledDependencies returns the dependencies of a platform that are installed
+func GetInstalledDependencies(config dbaasv1beta1.PlatformConfig, platforms map[dbaasv1beta1.PlatformName]dbaasv1beta1.PlatformConfig) []dbaasv1beta1.PlatformName {
+	var dependencies []dbaasv1beta1.PlatformName
+	for _, dependency := range config.DependsOn {
+		if _, installed := platforms[dependency]; installed {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies
+}
+
+func sortPlatformNames(names []dbaasv1beta1.PlatformName) {
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+}
@@ -0,0 +1,50 @@
+package reconcilers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+
+	dbaasv1beta1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+var _ = Describe("GetInstallationOrder", func() {
+	It("should install the built-in platforms in dependency order", func() {
+		levels, err := GetInstallationOrder(InstallationPlatforms)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(levels).To(Equal([][]dbaasv1beta1.PlatformName{
+			{
+				dbaasv1beta1.CockroachDBInstallation,
+				dbaasv1beta1.CrunchyBridgeInstallation,
+				dbaasv1beta1.ObservabilityInstallation,
+				dbaasv1beta1.RDSProviderInstallation,
+			},
+			{
+				dbaasv1beta1.DBaaSDynamicPluginInstallation,
+			},
+			{
+				dbaasv1beta1.DBaaSQuickStartInstallation,
+			},
+		}))
+	})
+
+	It("should ignore the dependencies that are not installed", func() {
+		platforms, err := GetInstallationPlatforms([]dbaasv1beta1.PlatformSpec{
+			{Name: dbaasv1beta1.DBaaSDynamicPluginInstallation, Enabled: pointer.Bool(false)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		levels, err := GetInstallationOrder(platforms)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(levels).To(HaveLen(1))
+		Expect(levels[0]).To(ContainElement(dbaasv1beta1.DBaaSQuickStartInstallation))
+	})
+
+	It("should fail on circular dependencies", func() {
+		platforms, err := GetInstallationPlatforms([]dbaasv1beta1.PlatformSpec{
+			{Name: dbaasv1beta1.DBaaSDynamicPluginInstallation, DependsOn: []dbaasv1beta1.PlatformName{dbaasv1beta1.DBaaSQuickStartInstallation}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = GetInstallationOrder(platforms)
+		Expect(err).To(MatchError("circular dependency between platforms dbaas-dynamic-plugin, dbaas-quick-starts"))
+	})
+})
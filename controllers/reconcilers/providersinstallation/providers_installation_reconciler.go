@@ -39,7 +39,7 @@ func NewReconciler(client client.Client, scheme *runtime.Scheme, logger logr.Log
 // Reconcile reconcile a DBaaSPlatform by creating the catalog source, a subscription and operator group
 func (r *reconciler) Reconcile(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1beta1.PlatformInstlnStatus, error) {
 
-	status, err := r.reconcileCatalogSource(ctx)
+	status, err := r.reconcileCatalogSource(ctx, cr)
 	if status != v1beta1.ResultSuccess {
 		return status, err
 	}
@@ -53,6 +53,10 @@ func (r *reconciler) Reconcile(ctx context.Context, cr *v1beta1.DBaaSPlatform) (
 	if status != v1beta1.ResultSuccess {
 		return status, err
 	}
+	status, err = r.reconcileInstallPlan(ctx, cr)
+	if status != v1beta1.ResultSuccess {
+		return status, err
+	}
 	status, err = r.waitForOperator(ctx, cr)
 	if status != v1beta1.ResultSuccess {
 		return status, err
@@ -70,13 +74,18 @@ func (r *reconciler) Reconcile(ctx context.Context, cr *v1beta1.DBaaSPlatform) (
 // Cleanup cleanup resources associated with the DBaaSPlatform
 func (r *reconciler) Cleanup(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1beta1.PlatformInstlnStatus, error) {
 
+	csvName, err := r.getCSVName(ctx, cr)
+	if err != nil {
+		return v1beta1.ResultFailed, err
+	}
+
 	subscription := reconcilers.GetSubscription(cr.Namespace, r.config.Name+"-subscription")
-	err := r.client.Delete(ctx, subscription)
+	err = r.client.Delete(ctx, subscription)
 	if err != nil && !errors.IsNotFound(err) {
 		return v1beta1.ResultFailed, err
 	}
 
-	catalogSource := reconcilers.GetCatalogSource(reconcilers.CatalogNamespace, r.config.Name+"-catalogsource")
+	catalogSource := reconcilers.GetCatalogSource(reconcilers.GetCatalogNamespace(cr), r.config.Name+"-catalogsource")
 	err = r.client.Delete(ctx, catalogSource)
 	if err != nil && !errors.IsNotFound(err) {
 		return v1beta1.ResultFailed, err
@@ -91,7 +100,7 @@ func (r *reconciler) Cleanup(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1
 	}
 
 	for d := range deployments.Items {
-		if deployments.Items[d].Name == r.config.DeploymentName {
+		if len(r.config.DeploymentName) > 0 && deployments.Items[d].Name == r.config.DeploymentName {
 			err = r.client.Delete(ctx, &deployments.Items[d])
 			if err != nil && !errors.IsNotFound(err) {
 				return v1beta1.ResultFailed, err
@@ -99,18 +108,114 @@ func (r *reconciler) Cleanup(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1
 		}
 	}
 
-	csv := reconcilers.GetClusterServiceVersion(cr.Namespace, r.config.CSV)
-	err = r.client.Delete(ctx, csv)
-	if err != nil && !errors.IsNotFound(err) {
-		return v1beta1.ResultFailed, err
+	if len(csvName) > 0 {
+		csv := reconcilers.GetClusterServiceVersion(cr.Namespace, csvName)
+		err = r.client.Delete(ctx, csv)
+		if err != nil && !errors.IsNotFound(err) {
+			return v1beta1.ResultFailed, err
+		}
 	}
 
 	return v1beta1.ResultSuccess, nil
 }
+
+// getCSVName returns the CSV of the operator, as installed by the subscription or as set in the platform settings
+func (r *reconciler) getCSVName(ctx context.Context, cr *v1beta1.DBaaSPlatform) (string, error) {
+	subscription := reconcilers.GetSubscription(cr.Namespace, r.config.Name+"-subscription")
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(subscription), subscription); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", err
+		}
+	} else if len(subscription.Status.InstalledCSV) > 0 {
+		return subscription.Status.InstalledCSV, nil
+	}
+	return r.config.CSV, nil
+}
+
+// reconcileInstallPlan approves the pending install plan of the subscription, if it installs the starting or the target CSV
+func (r *reconciler) reconcileInstallPlan(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1beta1.PlatformInstlnStatus, error) {
+	if r.config.Approval != v1beta1.InstallPlanApprovalManual {
+		return v1beta1.ResultSuccess, nil
+	}
+	installPlan, err := r.getPendingInstallPlan(ctx, cr)
+	if err != nil {
+		return v1beta1.ResultFailed, err
+	}
+	if installPlan == nil || !r.isApproved(installPlan) {
+		return v1beta1.ResultSuccess, nil
+	}
+	installPlan.Spec.Approved = true
+	if err := r.client.Update(ctx, installPlan); err != nil {
+		return v1beta1.ResultFailed, err
+	}
+	r.logger.Info("Install plan approved", "installPlan", installPlan.Name, "csvNames", installPlan.Spec.ClusterServiceVersionNames)
+	return v1beta1.ResultInProgress, nil
+}
+
+// getPendingInstallPlan returns the install plan of the subscription waiting for approval, or nil
+func (r *reconciler) getPendingInstallPlan(ctx context.Context, cr *v1beta1.DBaaSPlatform) (*v1alpha1.InstallPlan, error) {
+	subscription := reconcilers.GetSubscription(cr.Namespace, r.config.Name+"-subscription")
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(subscription), subscription); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if subscription.Status.InstallPlanRef == nil {
+		return nil, nil
+	}
+	installPlan := &v1alpha1.InstallPlan{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: subscription.Status.InstallPlanRef.Namespace, Name: subscription.Status.InstallPlanRef.Name}, installPlan); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if installPlan.Spec.Approved || installPlan.Status.Phase != v1alpha1.InstallPlanPhaseRequiresApproval {
+		return nil, nil
+	}
+	return installPlan, nil
+}
+
+// isApproved returns true if the install plan installs the starting or the target CSV
+func (r *reconciler) isApproved(installPlan *v1alpha1.InstallPlan) bool {
+	for _, csvName := range installPlan.Spec.ClusterServiceVersionNames {
+		if (len(r.config.TargetCSV) > 0 && csvName == r.config.TargetCSV) || (len(r.config.CSV) > 0 && csvName == r.config.CSV) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReportStatus sets the installed CSV and the install plans waiting for approval in the platform status
+func (r *reconciler) ReportStatus(ctx context.Context, cr *v1beta1.DBaaSPlatform, status *v1beta1.PlatformStatus) error {
+	subscription := reconcilers.GetSubscription(cr.Namespace, r.config.Name+"-subscription")
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(subscription), subscription); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	status.InstalledCSV = subscription.Status.InstalledCSV
+
+	installPlan, err := r.getPendingInstallPlan(ctx, cr)
+	if err != nil {
+		return err
+	}
+	if installPlan != nil {
+		status.PendingInstallPlans = []v1beta1.PendingInstallPlan{
+			{
+				Name:     installPlan.Name,
+				CSVNames: installPlan.Spec.ClusterServiceVersionNames,
+			},
+		}
+	}
+	return nil
+}
 func (r *reconciler) reconcileSubscription(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1beta1.PlatformInstlnStatus, error) {
 
 	subscription := reconcilers.GetSubscription(cr.Namespace, r.config.Name+"-subscription")
-	catalogsource := reconcilers.GetCatalogSource(reconcilers.CatalogNamespace, r.config.Name+"-catalogsource")
+	catalogsource := reconcilers.GetCatalogSource(reconcilers.GetCatalogNamespace(cr), r.config.Name+"-catalogsource")
 	_, err := controllerutil.CreateOrUpdate(ctx, r.client, subscription, func() error {
 		if err := ctrl.SetControllerReference(cr, subscription, r.scheme); err != nil {
 			return err
@@ -122,6 +227,9 @@ func (r *reconciler) reconcileSubscription(ctx context.Context, cr *v1beta1.DBaa
 			Channel:                r.config.Channel,
 			InstallPlanApproval:    v1alpha1.ApprovalAutomatic,
 		}
+		if r.config.Approval == v1beta1.InstallPlanApprovalManual {
+			subscription.Spec.InstallPlanApproval = v1alpha1.ApprovalManual
+		}
 		if r.config.CSV != "" {
 			subscription.Spec.StartingCSV = r.config.CSV
 		}
@@ -158,8 +266,8 @@ func (r *reconciler) reconcileOperatorGroup(ctx context.Context) (v1beta1.Platfo
 
 	return v1beta1.ResultSuccess, nil
 }
-func (r *reconciler) reconcileCatalogSource(ctx context.Context) (v1beta1.PlatformInstlnStatus, error) {
-	catalogsource := reconcilers.GetCatalogSource(reconcilers.CatalogNamespace, r.config.Name+"-catalogsource")
+func (r *reconciler) reconcileCatalogSource(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1beta1.PlatformInstlnStatus, error) {
+	catalogsource := reconcilers.GetCatalogSource(reconcilers.GetCatalogNamespace(cr), r.config.Name+"-catalogsource")
 	_, err := controllerutil.CreateOrUpdate(ctx, r.client, catalogsource, func() error {
 		catalogsource.Spec = v1alpha1.CatalogSourceSpec{
 			SourceType:  v1alpha1.SourceTypeGrpc,
@@ -188,6 +296,9 @@ func (r *reconciler) waitForOperator(ctx context.Context, cr *v1beta1.DBaaSPlatf
 		return v1beta1.ResultFailed, err
 	}
 
+	if len(r.config.DeploymentName) == 0 {
+		return r.waitForCSV(ctx, cr)
+	}
 	for _, deployment := range deployments.Items {
 		if deployment.Name == r.config.DeploymentName {
 			if deployment.Status.ReadyReplicas > 0 {
@@ -198,8 +309,37 @@ func (r *reconciler) waitForOperator(ctx context.Context, cr *v1beta1.DBaaSPlatf
 	return v1beta1.ResultInProgress, nil
 }
 
+// waitForCSV waits for the CSV of an operator without a known deployment name to succeed
+func (r *reconciler) waitForCSV(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1beta1.PlatformInstlnStatus, error) {
+	csvName, err := r.getCSVName(ctx, cr)
+	if err != nil {
+		return v1beta1.ResultFailed, err
+	}
+	if len(csvName) == 0 {
+		return v1beta1.ResultInProgress, nil
+	}
+	csv := reconcilers.GetClusterServiceVersion(cr.Namespace, csvName)
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(csv), csv); err != nil {
+		if errors.IsNotFound(err) {
+			return v1beta1.ResultInProgress, nil
+		}
+		return v1beta1.ResultFailed, err
+	}
+	if csv.Status.Phase == v1alpha1.CSVPhaseSucceeded {
+		return v1beta1.ResultSuccess, nil
+	}
+	return v1beta1.ResultInProgress, nil
+}
+
 func (r *reconciler) reconcileCSV(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1beta1.PlatformInstlnStatus, error) {
-	csv := reconcilers.GetClusterServiceVersion(cr.Namespace, r.config.CSV)
+	csvName, err := r.getCSVName(ctx, cr)
+	if err != nil {
+		return v1beta1.ResultFailed, err
+	}
+	if len(csvName) == 0 {
+		return v1beta1.ResultInProgress, nil
+	}
+	csv := reconcilers.GetClusterServiceVersion(cr.Namespace, csvName)
 	if err := r.client.Get(ctx, client.ObjectKeyFromObject(csv), csv); err != nil {
 		if errors.IsNotFound(err) {
 			return v1beta1.ResultInProgress, nil
@@ -11,3 +11,8 @@ type PlatformReconciler interface {
 	Reconcile(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1beta1.PlatformInstlnStatus, error)
 	Cleanup(ctx context.Context, cr *v1beta1.DBaaSPlatform) (v1beta1.PlatformInstlnStatus, error)
 }
+
+// PlatformStatusReporter interface for platform reconcilers reporting details in the platform status
+type PlatformStatusReporter interface {
+	ReportStatus(ctx context.Context, cr *v1beta1.DBaaSPlatform, status *v1beta1.PlatformStatus) error
+}
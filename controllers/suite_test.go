@@ -37,6 +37,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -55,12 +56,16 @@ import (
 // http://onsi.github.io/ginkgo/ to learn more about Ginkgo.
 
 var testEnv *envtest.Environment
+var cfg *rest.Config
 var ctx context.Context
 var cancel context.CancelFunc
 var dRec *DBaaSReconciler
 var iCtrl *spyctrl
 var cCtrl *spyctrl
 var inCtrl *spyctrl
+var bCtrl *spyctrl
+var rCtrl *spyctrl
+var pRec *DBaaSProviderReconciler
 
 const (
 	testNamespace = "default"
@@ -104,7 +109,7 @@ var _ = BeforeSuite(func() {
 		},
 	}
 
-	cfg, err := testEnv.Start()
+	cfg, err = testEnv.Start()
 	Expect(err).NotTo(HaveOccurred())
 	Expect(cfg).NotTo(BeNil())
 
@@ -131,6 +136,9 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred())
 	Expect(k8sManager).NotTo(BeNil())
 
+	err = v1beta1.SetupIndexes(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = (&v1beta1.DBaaSConnection{}).SetupWebhookWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -172,6 +180,16 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	backupCtrl, err := (&DBaaSBackupReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	restoreCtrl, err := (&DBaaSRestoreReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&DBaaSDefaultPolicyReconciler{
 		DBaaSReconciler: dRec,
 	}).SetupWithManager(k8sManager)
@@ -180,13 +198,18 @@ var _ = BeforeSuite(func() {
 	iCtrl = newSpyController(inventoryCtrl)
 	cCtrl = newSpyController(connectionCtrl)
 	inCtrl = newSpyController(instanceCtrl)
+	bCtrl = newSpyController(backupCtrl)
+	rCtrl = newSpyController(restoreCtrl)
 
-	err = (&DBaaSProviderReconciler{
+	pRec = &DBaaSProviderReconciler{
 		DBaaSReconciler: dRec,
 		InventoryCtrl:   iCtrl,
 		ConnectionCtrl:  cCtrl,
 		InstanceCtrl:    inCtrl,
-	}).SetupWithManager(k8sManager)
+		BackupCtrl:      bCtrl,
+		RestoreCtrl:     rCtrl,
+	}
+	err = pRec.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	createCSV(k8sManager)
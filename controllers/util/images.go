@@ -0,0 +1,130 @@
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ImageMirror defines the mirrors of an image repository, as set in ImageContentSourcePolicy and ImageDigestMirrorSet objects
+type ImageMirror struct {
+	Source             string
+	Mirrors            []string
+	NeverContactSource bool
+}
+
+var imageMirrorListKinds = []struct {
+	gvk        schema.GroupVersionKind
+	mirrorsKey string
+}{
+	{gvk: schema.GroupVersionKind{Group: "operator.openshift.io", Version: "v1alpha1", Kind: "ImageContentSourcePolicyList"}, mirrorsKey: "repositoryDigestMirrors"},
+	{gvk: schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "ImageDigestMirrorSetList"}, mirrorsKey: "imageDigestMirrors"},
+}
+
+// GetImageMirrors returns the image mirrors of the cluster, the mirror APIs that are not available are ignored
+func GetImageMirrors(ctx context.Context, client k8sclient.Client) ([]ImageMirror, error) {
+	var mirrors []ImageMirror
+	for _, listKind := range imageMirrorListKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(listKind.gvk)
+		if err := client.List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, item := range list.Items {
+			entries, _, err := unstructured.NestedSlice(item.Object, "spec", listKind.mirrorsKey)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range entries {
+				fields, ok := entry.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				mirror := ImageMirror{}
+				mirror.Source, _, _ = unstructured.NestedString(fields, "source")
+				mirror.Mirrors, _, _ = unstructured.NestedStringSlice(fields, "mirrors")
+				policy, _, _ := unstructured.NestedString(fields, "mirrorSourcePolicy")
+				mirror.NeverContactSource = policy == "NeverContactSource"
+				if len(mirror.Source) > 0 {
+					mirrors = append(mirrors, mirror)
+				}
+			}
+		}
+	}
+	return mirrors, nil
+}
+
+// IsDigestPinned returns true if the image reference is pinned by digest
+func IsDigestPinned(image string) bool {
+	return strings.Contains(image, "@sha256:")
+}
+
+// GetImagePullSources returns the image references the image can be pulled from, in order.
+// The mirrors only apply to images pinned by digest.
+func GetImagePullSources(image string, mirrors []ImageMirror) []string {
+	if !IsDigestPinned(image) {
+		return []string{image}
+	}
+	repository := strings.SplitN(image, "@", 2)[0]
+	var sources []string
+	contactSource := true
+	for _, mirror := range mirrors {
+		if repository != mirror.Source && !strings.HasPrefix(repository, mirror.Source+"/") {
+			continue
+		}
+		for _, m := range mirror.Mirrors {
+			sources = append(sources, m+strings.TrimPrefix(image, mirror.Source))
+		}
+		if mirror.NeverContactSource {
+			contactSource = false
+		}
+	}
+	if contactSource {
+		sources = append(sources, image)
+	}
+	return sources
+}
+
+// GetImageRegistry returns the registry host of an image reference
+func GetImageRegistry(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 1 || (!strings.ContainsAny(parts[0], ".:") && parts[0] != "localhost") {
+		return "docker.io"
+	}
+	return parts[0]
+}
+
+// CheckRegistryReachable returns an error if the registry API does not respond, it is a variable so it can be replaced in tests
+var CheckRegistryReachable = func(ctx context.Context, registry string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	host := registry
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s/v2/", host), nil)
+	if err != nil {
+		return err
+	}
+	// only the connectivity is checked, the registries of disconnected clusters often use a custom certificate authority
+	client := &http.Client{Transport: &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //#nosec G402
+	}}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
@@ -51,6 +51,7 @@ import (
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/localprovider"
 	metrics "github.com/RHEcosystemAppEng/dbaas-operator/controllers/metrics"
 	//+kubebuilder:scaffold:imports
 )
@@ -135,6 +136,10 @@ func main() {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
+	if err = v1beta1.SetupIndexes(mgr); err != nil {
+		setupLog.Error(err, "unable to set up the field indexes")
+		os.Exit(1)
+	}
 
 	DBaaSReconciler := &controllers.DBaaSReconciler{
 		Client: mgr.GetClient(),
@@ -164,6 +169,20 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSInstance")
 		os.Exit(1)
 	}
+	backupCtrl, err := (&controllers.DBaaSBackupReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSBackup")
+		os.Exit(1)
+	}
+	restoreCtrl, err := (&controllers.DBaaSRestoreReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSRestore")
+		os.Exit(1)
+	}
 	if err = (&controllers.DBaaSDefaultPolicyReconciler{
 		DBaaSReconciler: DBaaSReconciler,
 	}).SetupWithManager(mgr); err != nil {
@@ -175,6 +194,8 @@ func main() {
 		ConnectionCtrl:  connectionCtrl,
 		InventoryCtrl:   inventoryCtrl,
 		InstanceCtrl:    instanceCtrl,
+		BackupCtrl:      backupCtrl,
+		RestoreCtrl:     restoreCtrl,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSProvider")
 		os.Exit(1)
@@ -202,6 +223,18 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSProvider")
 			os.Exit(1)
 		}
+		if err = (&v1beta1.DBaaSInstanceApproval{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSInstanceApproval")
+			os.Exit(1)
+		}
+		if err = (&v1beta1.DBaaSBackup{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSBackup")
+			os.Exit(1)
+		}
+		if err = (&v1beta1.DBaaSRestore{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSRestore")
+			os.Exit(1)
+		}
 	}
 	if err = (&controllers.DBaaSPolicyReconciler{
 		DBaaSReconciler: DBaaSReconciler,
@@ -217,6 +250,12 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSPlatform")
 		os.Exit(1)
 	}
+	if os.Getenv(localprovider.EnvEnableLocalProvider) == "true" {
+		if err = localprovider.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "LocalProvider")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
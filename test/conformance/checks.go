@@ -0,0 +1,189 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// instancePhases are the phases of the DBaaSInstanceStatus enum
+var instancePhases = map[v1beta1.DBaasInstancePhase]bool{
+	v1beta1.InstancePhaseUnknown:  true,
+	v1beta1.InstancePhasePending:  true,
+	v1beta1.InstancePhaseCreating: true,
+	v1beta1.InstancePhaseUpdating: true,
+	v1beta1.InstancePhaseDeleting: true,
+	v1beta1.InstancePhaseDeleted:  true,
+	v1beta1.InstancePhaseReady:    true,
+	v1beta1.InstancePhaseError:    true,
+	v1beta1.InstancePhaseFailed:   true,
+}
+
+// CheckSyncCondition checks that the provider object reports the condition the DBaaS Operator copies to the DBaaS object.
+// It returns the condition.
+func CheckSyncCondition(conditions []metav1.Condition, conditionType string) (*metav1.Condition, error) {
+	condition := apimeta.FindStatusCondition(conditions, conditionType)
+	if condition == nil {
+		return nil, fmt.Errorf("the status has no %s condition", conditionType)
+	}
+	switch condition.Status {
+	case metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown:
+	default:
+		return nil, fmt.Errorf("the %s condition has the invalid status %q", conditionType, condition.Status)
+	}
+	if len(condition.Reason) == 0 {
+		return nil, fmt.Errorf("the %s condition has no reason", conditionType)
+	}
+	return condition, nil
+}
+
+// CheckInventoryStatus checks the status of a provider inventory, as merged in the DBaaSInventory
+func CheckInventoryStatus(inventory *v1beta1.DBaaSProviderInventory) error {
+	if _, err := CheckSyncCondition(inventory.Status.Conditions, v1beta1.DBaaSInventoryProviderSyncType); err != nil {
+		return err
+	}
+	serviceIDs := map[string]bool{}
+	for i, service := range inventory.Status.DatabaseServices {
+		if len(service.ServiceID) == 0 {
+			return fmt.Errorf("the database service %d has no serviceID", i)
+		}
+		if serviceIDs[service.ServiceID] {
+			return fmt.Errorf("the serviceID %s is reported more than once", service.ServiceID)
+		}
+		serviceIDs[service.ServiceID] = true
+	}
+	return nil
+}
+
+// CheckConnectionStatus checks the status of a provider connection, as merged in the DBaaSConnection.
+// The credentials and connection information references are required once the connection is ready for binding.
+func CheckConnectionStatus(connection *v1beta1.DBaaSProviderConnection) error {
+	condition, err := CheckSyncCondition(connection.Status.Conditions, v1beta1.DBaaSConnectionProviderSyncType)
+	if err != nil {
+		return err
+	}
+	if condition.Status != metav1.ConditionTrue {
+		return nil
+	}
+	if connection.Status.CredentialsRef == nil || len(connection.Status.CredentialsRef.Name) == 0 {
+		return fmt.Errorf("the connection is ready for binding without credentialsRef")
+	}
+	if connection.Status.ConnectionInfoRef == nil || len(connection.Status.ConnectionInfoRef.Name) == 0 {
+		return fmt.Errorf("the connection is ready for binding without connectionInfoRef")
+	}
+	if connection.Status.Binding != nil {
+		return fmt.Errorf("the binding is set by the DBaaS Operator, not by the provider")
+	}
+	return nil
+}
+
+// CheckInstanceStatus checks the status of a provider instance, as merged in the DBaaSInstance.
+// The instance ID is required once the instance is provisioned.
+func CheckInstanceStatus(instance *v1beta1.DBaaSProviderInstance) error {
+	condition, err := CheckSyncCondition(instance.Status.Conditions, v1beta1.DBaaSInstanceProviderSyncType)
+	if err != nil {
+		return err
+	}
+	if !instancePhases[instance.Status.Phase] {
+		return fmt.Errorf("the instance has the invalid phase %q", instance.Status.Phase)
+	}
+	if condition.Status == metav1.ConditionTrue && len(instance.Status.InstanceID) == 0 {
+		return fmt.Errorf("the instance is provisioned without instanceID")
+	}
+	return nil
+}
+
+// CheckOwnerReference checks that the provider object is still controlled by the DBaaS object,
+// the DBaaS Operator watches the provider objects through this reference
+func CheckOwnerReference(providerObject, owner client.Object) error {
+	ref := metav1.GetControllerOf(providerObject)
+	if ref == nil {
+		return fmt.Errorf("the %s has no controller owner reference", providerObject.GetName())
+	}
+	if ref.UID != owner.GetUID() {
+		return fmt.Errorf("the %s is controlled by %s %s instead of %s", providerObject.GetName(), ref.Kind, ref.Name, owner.GetName())
+	}
+	return nil
+}
+
+// CheckCredentialsLabel checks that the inventory credentials secret keeps the label set by the DBaaS Operator
+func CheckCredentialsLabel(secret *corev1.Secret, providerName string) error {
+	key := v1beta1.GetCredentialsLabelKey(providerName)
+	if secret.Labels[key] != v1beta1.TypeLabelValue {
+		return fmt.Errorf("the credentials secret %s has no %s=%s label", secret.Name, key, v1beta1.TypeLabelValue)
+	}
+	return nil
+}
+
+// CheckConnectionObjects checks that the secret and ConfigMap referenced by a ready connection exist in the namespace
+// of the provider connection, with the entries used to generate the binding secret. The namespace is passed explicitly,
+// as the connections converted from the v1alpha1 provider objects only hold the status.
+func CheckConnectionObjects(ctx context.Context, c client.Client, namespace string, connection *v1beta1.DBaaSProviderConnection) error {
+	if connection.Status.CredentialsRef == nil || connection.Status.ConnectionInfoRef == nil {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: connection.Status.CredentialsRef.Name, Namespace: namespace}, secret); err != nil {
+		return fmt.Errorf("error reading the credentials secret: %w", err)
+	}
+	for _, key := range []string{"username", "password"} {
+		if len(secret.Data[key]) == 0 && len(secret.StringData[key]) == 0 {
+			return fmt.Errorf("the credentials secret %s has no %s entry", secret.Name, key)
+		}
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: connection.Status.ConnectionInfoRef.Name, Namespace: namespace}, configMap); err != nil {
+		return fmt.Errorf("error reading the connection information ConfigMap: %w", err)
+	}
+	if len(configMap.Data["host"]) == 0 {
+		return fmt.Errorf("the connection information ConfigMap %s has no host entry", configMap.Name)
+	}
+	return nil
+}
+
+// parseProviderObject parses a provider object into a provider object of the converter, as done by the DBaaS Operator
+func parseProviderObject(providerObject *unstructured.Unstructured, object interface{}) error {
+	b, err := providerObject.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, object)
+}
+
+// toUnstructuredContent converts a provider object spec to unstructured content
+func toUnstructuredContent(spec interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	content := map[string]interface{}{}
+	if err := json.Unmarshal(b, &content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
@@ -0,0 +1,156 @@
+package conformance
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+func newCondition(conditionType string, status metav1.ConditionStatus) metav1.Condition {
+	return metav1.Condition{Type: conditionType, Status: status, Reason: "Test"}
+}
+
+var _ = Describe("Status checks", func() {
+	It("should require the sync condition", func() {
+		_, err := CheckSyncCondition(nil, v1beta1.DBaaSInventoryProviderSyncType)
+		Expect(err).To(MatchError("the status has no SpecSynced condition"))
+		_, err = CheckSyncCondition([]metav1.Condition{{Type: v1beta1.DBaaSInventoryProviderSyncType, Status: "Yes", Reason: "Test"}},
+			v1beta1.DBaaSInventoryProviderSyncType)
+		Expect(err).To(MatchError(`the SpecSynced condition has the invalid status "Yes"`))
+		_, err = CheckSyncCondition([]metav1.Condition{{Type: v1beta1.DBaaSInventoryProviderSyncType, Status: metav1.ConditionTrue}},
+			v1beta1.DBaaSInventoryProviderSyncType)
+		Expect(err).To(MatchError("the SpecSynced condition has no reason"))
+		condition, err := CheckSyncCondition([]metav1.Condition{newCondition(v1beta1.DBaaSInventoryProviderSyncType, metav1.ConditionFalse)},
+			v1beta1.DBaaSInventoryProviderSyncType)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+	})
+
+	It("should check the inventory database services", func() {
+		inventory := &v1beta1.DBaaSProviderInventory{
+			Status: v1beta1.DBaaSInventoryStatus{
+				Conditions:       []metav1.Condition{newCondition(v1beta1.DBaaSInventoryProviderSyncType, metav1.ConditionTrue)},
+				DatabaseServices: []v1beta1.DatabaseService{{ServiceID: "id1"}, {ServiceID: "id2"}},
+			},
+		}
+		Expect(CheckInventoryStatus(inventory)).To(Succeed())
+		inventory.Status.DatabaseServices = append(inventory.Status.DatabaseServices, v1beta1.DatabaseService{ServiceID: "id1"})
+		Expect(CheckInventoryStatus(inventory)).To(MatchError("the serviceID id1 is reported more than once"))
+		inventory.Status.DatabaseServices = []v1beta1.DatabaseService{{ServiceName: "test"}}
+		Expect(CheckInventoryStatus(inventory)).To(MatchError("the database service 0 has no serviceID"))
+	})
+
+	It("should require the connection references once ready for binding", func() {
+		connection := &v1beta1.DBaaSProviderConnection{
+			Status: v1beta1.DBaaSConnectionStatus{
+				Conditions: []metav1.Condition{newCondition(v1beta1.DBaaSConnectionProviderSyncType, metav1.ConditionFalse)},
+			},
+		}
+		Expect(CheckConnectionStatus(connection)).To(Succeed())
+		connection.Status.Conditions[0].Status = metav1.ConditionTrue
+		Expect(CheckConnectionStatus(connection)).To(MatchError("the connection is ready for binding without credentialsRef"))
+		connection.Status.CredentialsRef = &corev1.LocalObjectReference{Name: "test-credentials"}
+		Expect(CheckConnectionStatus(connection)).To(MatchError("the connection is ready for binding without connectionInfoRef"))
+		connection.Status.ConnectionInfoRef = &corev1.LocalObjectReference{Name: "test-info"}
+		Expect(CheckConnectionStatus(connection)).To(Succeed())
+		connection.Status.Binding = &corev1.LocalObjectReference{Name: "test-binding"}
+		Expect(CheckConnectionStatus(connection)).To(HaveOccurred())
+	})
+
+	It("should check the instance phase and ID", func() {
+		instance := &v1beta1.DBaaSProviderInstance{
+			Status: v1beta1.DBaaSInstanceStatus{
+				Conditions: []metav1.Condition{newCondition(v1beta1.DBaaSInstanceProviderSyncType, metav1.ConditionFalse)},
+				Phase:      v1beta1.InstancePhaseCreating,
+			},
+		}
+		Expect(CheckInstanceStatus(instance)).To(Succeed())
+		instance.Status.Conditions[0].Status = metav1.ConditionTrue
+		instance.Status.Phase = v1beta1.InstancePhaseReady
+		Expect(CheckInstanceStatus(instance)).To(MatchError("the instance is provisioned without instanceID"))
+		instance.Status.InstanceID = "test-id"
+		Expect(CheckInstanceStatus(instance)).To(Succeed())
+		instance.Status.Phase = "Provisioning"
+		Expect(CheckInstanceStatus(instance)).To(MatchError(`the instance has the invalid phase "Provisioning"`))
+	})
+})
+
+var _ = Describe("Object checks", func() {
+	It("should require the controller reference of the DBaaS object", func() {
+		owner := &v1beta1.DBaaSInventory{ObjectMeta: metav1.ObjectMeta{Name: "test-inventory", UID: types.UID("owner-uid")}}
+		providerObject := &unstructured.Unstructured{}
+		providerObject.SetName("test-inventory")
+		Expect(CheckOwnerReference(providerObject, owner)).To(MatchError("the test-inventory has no controller owner reference"))
+
+		controller := true
+		providerObject.SetOwnerReferences([]metav1.OwnerReference{{Kind: "Other", Name: "other", UID: "other-uid", Controller: &controller}})
+		Expect(CheckOwnerReference(providerObject, owner)).To(MatchError("the test-inventory is controlled by Other other instead of test-inventory"))
+
+		providerObject.SetOwnerReferences([]metav1.OwnerReference{{Kind: "DBaaSInventory", Name: "test-inventory", UID: "owner-uid", Controller: &controller}})
+		Expect(CheckOwnerReference(providerObject, owner)).To(Succeed())
+	})
+
+	It("should require the credentials label of the provider", func() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-credentials"}}
+		Expect(CheckCredentialsLabel(secret, "test-registration")).To(MatchError("the credentials secret test-credentials has no db-operator/type=credentials label"))
+		secret.Labels = map[string]string{v1beta1.TypeLabelKey: v1beta1.TypeLabelValue}
+		Expect(CheckCredentialsLabel(secret, "test-registration")).To(Succeed())
+		Expect(CheckCredentialsLabel(secret, "mongodb-atlas-registration")).To(HaveOccurred())
+	})
+
+	It("should parse the provider objects as the DBaaS Operator", func() {
+		spec := &v1beta1.DBaaSInventorySpec{CredentialsRef: &v1beta1.LocalObjectReference{Name: "test-credentials"}}
+		content, err := toUnstructuredContent(spec)
+		Expect(err).NotTo(HaveOccurred())
+		providerObject := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "dbaas.redhat.com/v1beta1",
+			"kind":       "TestInventory",
+			"metadata":   map[string]interface{}{"name": "test-inventory", "namespace": "test-ns"},
+			"spec":       content,
+			"status": map[string]interface{}{
+				"databaseServices": []interface{}{map[string]interface{}{"serviceID": "id1"}},
+			},
+		}}
+		inventory := &v1beta1.DBaaSProviderInventory{}
+		Expect(parseProviderObject(providerObject, inventory)).To(Succeed())
+		Expect(inventory.Namespace).To(Equal("test-ns"))
+		Expect(inventory.Spec.CredentialsRef.Name).To(Equal("test-credentials"))
+		Expect(inventory.Status.DatabaseServices[0].ServiceID).To(Equal("id1"))
+	})
+})
+
+var _ = Describe("Connection objects check", func() {
+	It("should read the objects in the namespace of the provider connection", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-credentials", Namespace: "test-ns"},
+				Data:       map[string][]byte{"username": []byte("user"), "password": []byte("secret")},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-info", Namespace: "test-ns"},
+				Data:       map[string]string{"host": "db.example.com"},
+			},
+		).Build()
+		// a connection converted from a v1alpha1 provider object only holds the status
+		connection := &v1beta1.DBaaSProviderConnection{
+			Status: v1beta1.DBaaSConnectionStatus{
+				CredentialsRef:    &corev1.LocalObjectReference{Name: "test-credentials"},
+				ConnectionInfoRef: &corev1.LocalObjectReference{Name: "test-info"},
+			},
+		}
+		Expect(CheckConnectionObjects(context.Background(), c, "test-ns", connection)).To(Succeed())
+		Expect(CheckConnectionObjects(context.Background(), c, "other-ns", connection)).To(HaveOccurred())
+	})
+})
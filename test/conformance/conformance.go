@@ -0,0 +1,371 @@
+/*
+Copyright 2023 The OpenShift Database Access Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance checks that a provider operator implements the contract of the DBaaS Operator, described in
+// docs/provider-guide. Provider operators run it from their envtest suite, with their controllers running:
+//
+//	var _ = conformance.DescribeProvider(func() *conformance.Provider {
+//		return &conformance.Provider{Registration: registration, Client: k8sClient, Namespace: "default", ...}
+//	})
+//
+// The suite creates the provider objects as the DBaaS Operator does, owned by DBaaS objects. The DBaaS custom resource
+// definitions must be installed, the DBaaS Operator controllers and webhooks are not needed.
+package conformance
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/providerapi"
+)
+
+const (
+	defaultTimeout  = time.Minute
+	defaultInterval = time.Second
+
+	// conformanceLabel is set on the objects created by the suite, and on the provider objects when they are updated
+	conformanceLabel = "dbaas.redhat.com/conformance"
+)
+
+// Provider is the provider under test
+type Provider struct {
+	// The DBaaSProvider registering the provider, it is created if it does not exist.
+	Registration *v1beta1.DBaaSProvider
+
+	// The client creating the objects, it must not cache the secrets and ConfigMaps unless the provider watches them.
+	Client client.Client
+
+	// The namespace of the objects created by the suite.
+	Namespace string
+
+	// The data of the inventory credentials secret, with the keys of the credential fields of the provider.
+	Credentials map[string][]byte
+
+	// The provisioning parameters of the provider instance. The instance checks are skipped if it is not set.
+	ProvisioningParameters map[v1beta1.ProvisioningParameterType]string
+
+	// The ID of the database service to connect to. The first database service of the inventory is used if it is not set,
+	// and the connection checks are skipped if the inventory has none.
+	DatabaseServiceID string
+
+	// How long to wait for the provider to reconcile an object, one minute by default.
+	Timeout time.Duration
+
+	// How often the provider objects are checked while waiting, one second by default.
+	Interval time.Duration
+}
+
+// DescribeProvider declares the conformance specs of a provider. The provider is returned by a function,
+// so it can be set up in BeforeSuite.
+func DescribeProvider(provider func() *Provider) bool {
+	return Describe("DBaaS provider conformance", func() {
+		var p *Provider
+		var converter providerapi.Converter
+		ctx := context.Background()
+
+		BeforeEach(func() {
+			p = provider()
+			if p.Timeout == 0 {
+				p.Timeout = defaultTimeout
+			}
+			if p.Interval == 0 {
+				p.Interval = defaultInterval
+			}
+			registration := p.Registration.DeepCopy()
+			if err := p.Client.Create(ctx, registration); err != nil {
+				Expect(errors.IsAlreadyExists(err)).To(BeTrue(), "error creating the DBaaSProvider: %v", err)
+			}
+			var err error
+			converter, err = providerapi.ForProvider(p.Registration)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("inventory", func() {
+			It("should sync the inventory status on create, update and delete", func() {
+				inventory, providerInventory := createInventory(ctx, p, converter)
+				status := waitForInventory(ctx, p, converter, inventory, providerInventory)
+				Expect(CheckInventoryStatus(status)).To(Succeed())
+
+				secret := &corev1.Secret{}
+				Expect(p.Client.Get(ctx, client.ObjectKey{Name: inventory.Spec.CredentialsRef.Name, Namespace: p.Namespace}, secret)).To(Succeed())
+				Expect(CheckCredentialsLabel(secret, p.Registration.Name)).To(Succeed())
+
+				By("updating the inventory")
+				updateProviderObject(ctx, p, inventory, providerInventory, func() (interface{}, error) {
+					return converter.InventorySpec(&inventory.Spec)
+				})
+				Expect(CheckInventoryStatus(waitForInventory(ctx, p, converter, inventory, providerInventory))).To(Succeed())
+
+				By("deleting the inventory")
+				deleteProviderObject(ctx, p, providerInventory)
+				Expect(p.Client.Delete(ctx, inventory)).To(Succeed())
+			})
+		})
+
+		Context("connection", func() {
+			It("should issue the connection credentials on create, update and delete", func() {
+				inventory, providerInventory := createInventory(ctx, p, converter)
+				defer func() {
+					deleteProviderObject(ctx, p, providerInventory)
+					Expect(p.Client.Delete(ctx, inventory)).To(Succeed())
+				}()
+				inventoryStatus := waitForInventory(ctx, p, converter, inventory, providerInventory)
+				serviceID := p.DatabaseServiceID
+				if len(serviceID) == 0 {
+					if len(inventoryStatus.Status.DatabaseServices) == 0 {
+						Skip("the inventory has no database service to connect to")
+					}
+					serviceID = inventoryStatus.Status.DatabaseServices[0].ServiceID
+				}
+
+				connection := &v1beta1.DBaaSConnection{
+					ObjectMeta: newObjectMeta(p, "conformance-connection"),
+					Spec: v1beta1.DBaaSConnectionSpec{
+						InventoryRef:      v1beta1.NamespacedName{Name: inventory.Name, Namespace: inventory.Namespace},
+						DatabaseServiceID: serviceID,
+					},
+				}
+				Expect(p.Client.Create(ctx, connection)).To(Succeed())
+				spec := func() (interface{}, error) {
+					return converter.ConnectionSpec(&connection.Spec)
+				}
+				providerConnection := createProviderObject(ctx, p, connection, p.Registration.Spec.ConnectionKind, spec)
+				status := waitForConnection(ctx, p, converter, connection, providerConnection)
+				Expect(CheckConnectionStatus(status)).To(Succeed())
+				Expect(CheckConnectionObjects(ctx, p.Client, providerConnection.GetNamespace(), status)).To(Succeed())
+
+				By("updating the connection")
+				updateProviderObject(ctx, p, connection, providerConnection, spec)
+				status = waitForConnection(ctx, p, converter, connection, providerConnection)
+				Expect(CheckConnectionStatus(status)).To(Succeed())
+				Expect(CheckConnectionObjects(ctx, p.Client, providerConnection.GetNamespace(), status)).To(Succeed())
+
+				By("deleting the connection")
+				deleteProviderObject(ctx, p, providerConnection)
+				Expect(p.Client.Delete(ctx, connection)).To(Succeed())
+			})
+		})
+
+		Context("instance", func() {
+			It("should report the provisioning status on create, update and delete", func() {
+				if p.ProvisioningParameters == nil {
+					Skip("no provisioning parameters are set for the provider")
+				}
+				inventory, providerInventory := createInventory(ctx, p, converter)
+				defer func() {
+					deleteProviderObject(ctx, p, providerInventory)
+					Expect(p.Client.Delete(ctx, inventory)).To(Succeed())
+				}()
+				waitForInventory(ctx, p, converter, inventory, providerInventory)
+
+				instance := &v1beta1.DBaaSInstance{
+					ObjectMeta: newObjectMeta(p, "conformance-instance"),
+					Spec: v1beta1.DBaaSInstanceSpec{
+						InventoryRef:           v1beta1.NamespacedName{Name: inventory.Name, Namespace: inventory.Namespace},
+						ProvisioningParameters: p.ProvisioningParameters,
+					},
+				}
+				Expect(p.Client.Create(ctx, instance)).To(Succeed())
+				spec := func() (interface{}, error) {
+					return converter.InstanceSpec(&instance.Spec)
+				}
+				providerInstance := createProviderObject(ctx, p, instance, p.Registration.Spec.InstanceKind, spec)
+				Expect(CheckInstanceStatus(waitForInstance(ctx, p, converter, instance, providerInstance))).To(Succeed())
+
+				By("updating the instance")
+				updateProviderObject(ctx, p, instance, providerInstance, spec)
+				Expect(CheckInstanceStatus(waitForInstance(ctx, p, converter, instance, providerInstance))).To(Succeed())
+
+				By("deleting the instance")
+				deleteProviderObject(ctx, p, providerInstance)
+				Expect(p.Client.Delete(ctx, instance)).To(Succeed())
+			})
+		})
+	})
+}
+
+func newObjectMeta(p *Provider, prefix string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      prefix + "-" + rand.String(5),
+		Namespace: p.Namespace,
+		Labels:    map[string]string{conformanceLabel: "true"},
+	}
+}
+
+// createInventory creates the credentials secret, the DBaaSInventory and the provider inventory
+func createInventory(ctx context.Context, p *Provider, converter providerapi.Converter) (*v1beta1.DBaaSInventory, *unstructured.Unstructured) {
+	secret := &corev1.Secret{
+		ObjectMeta: newObjectMeta(p, "conformance-credentials"),
+		Data:       p.Credentials,
+	}
+	secret.Labels[v1beta1.GetCredentialsLabelKey(p.Registration.Name)] = v1beta1.TypeLabelValue
+	Expect(p.Client.Create(ctx, secret)).To(Succeed())
+
+	inventory := &v1beta1.DBaaSInventory{
+		ObjectMeta: newObjectMeta(p, "conformance-inventory"),
+		Spec: v1beta1.DBaaSOperatorInventorySpec{
+			ProviderRef: v1beta1.NamespacedName{Name: p.Registration.Name},
+			DBaaSInventorySpec: v1beta1.DBaaSInventorySpec{
+				CredentialsRef: &v1beta1.LocalObjectReference{Name: secret.Name},
+			},
+		},
+	}
+	Expect(p.Client.Create(ctx, inventory)).To(Succeed())
+	Expect(controllerutil.SetOwnerReference(inventory, secret, p.Client.Scheme())).To(Succeed())
+	Expect(p.Client.Update(ctx, secret)).To(Succeed())
+
+	providerInventory := createProviderObject(ctx, p, inventory, p.Registration.Spec.InventoryKind, func() (interface{}, error) {
+		return converter.InventorySpec(&inventory.Spec)
+	})
+	return inventory, providerInventory
+}
+
+// createProviderObject creates the provider object of a DBaaS object, with the same name and namespace,
+// controlled by the DBaaS object
+func createProviderObject(ctx context.Context, p *Provider, owner client.Object, kind string, spec func() (interface{}, error)) *unstructured.Unstructured {
+	providerObject := &unstructured.Unstructured{}
+	providerObject.SetGroupVersionKind(p.Registration.GetDBaaSAPIGroupVersion().WithKind(kind))
+	providerObject.SetName(owner.GetName())
+	providerObject.SetNamespace(owner.GetNamespace())
+	Expect(setProviderObject(p, owner, providerObject, spec)).To(Succeed())
+	Expect(p.Client.Create(ctx, providerObject)).To(Succeed())
+	return providerObject
+}
+
+// updateProviderObject applies the spec of the DBaaS object to the provider object again, as the DBaaS Operator does
+// on every reconcile, and labels it to make a change
+func updateProviderObject(ctx context.Context, p *Provider, owner client.Object, providerObject *unstructured.Unstructured, spec func() (interface{}, error)) {
+	Eventually(func() error {
+		if err := p.Client.Get(ctx, client.ObjectKeyFromObject(providerObject), providerObject); err != nil {
+			return err
+		}
+		labels := providerObject.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[conformanceLabel] = "updated"
+		providerObject.SetLabels(labels)
+		if err := setProviderObject(p, owner, providerObject, spec); err != nil {
+			return err
+		}
+		return p.Client.Update(ctx, providerObject)
+	}, p.Timeout, p.Interval).Should(Succeed())
+}
+
+func setProviderObject(p *Provider, owner client.Object, providerObject *unstructured.Unstructured, spec func() (interface{}, error)) error {
+	providerSpec, err := spec()
+	if err != nil {
+		return err
+	}
+	// the spec is converted to unstructured content, so the object can be deep copied by the client
+	content, err := toUnstructuredContent(providerSpec)
+	if err != nil {
+		return err
+	}
+	providerObject.UnstructuredContent()["spec"] = content
+	providerObject.SetOwnerReferences(nil)
+	return controllerutil.SetControllerReference(owner, providerObject, p.Client.Scheme())
+}
+
+// deleteProviderObject deletes a provider object, and waits for the provider to remove its finalizers
+func deleteProviderObject(ctx context.Context, p *Provider, providerObject *unstructured.Unstructured) {
+	Expect(client.IgnoreNotFound(p.Client.Delete(ctx, providerObject))).To(Succeed())
+	Eventually(func() bool {
+		err := p.Client.Get(ctx, client.ObjectKeyFromObject(providerObject), providerObject.DeepCopy())
+		return errors.IsNotFound(err)
+	}, p.Timeout, p.Interval).Should(BeTrue(), "the %s %s is not deleted", providerObject.GetKind(), providerObject.GetName())
+}
+
+// waitForInventory waits for the provider to report the sync condition of the inventory, and returns the inventory
+func waitForInventory(ctx context.Context, p *Provider, converter providerapi.Converter, owner client.Object, providerInventory *unstructured.Unstructured) *v1beta1.DBaaSProviderInventory {
+	var status *v1beta1.DBaaSProviderInventory
+	Eventually(func() error {
+		current, err := getProviderObject(ctx, p, providerInventory, owner)
+		if err != nil {
+			return err
+		}
+		i := converter.NewInventory()
+		if err := parseProviderObject(current, i); err != nil {
+			return err
+		}
+		status = converter.ConvertInventory(i)
+		_, err = CheckSyncCondition(status.Status.Conditions, v1beta1.DBaaSInventoryProviderSyncType)
+		return err
+	}, p.Timeout, p.Interval).Should(Succeed())
+	return status
+}
+
+// waitForConnection waits for the provider to report the sync condition of the connection, and returns it
+func waitForConnection(ctx context.Context, p *Provider, converter providerapi.Converter, owner client.Object, providerConnection *unstructured.Unstructured) *v1beta1.DBaaSProviderConnection {
+	var status *v1beta1.DBaaSProviderConnection
+	Eventually(func() error {
+		current, err := getProviderObject(ctx, p, providerConnection, owner)
+		if err != nil {
+			return err
+		}
+		i := converter.NewConnection()
+		if err := parseProviderObject(current, i); err != nil {
+			return err
+		}
+		status = converter.ConvertConnection(i)
+		_, err = CheckSyncCondition(status.Status.Conditions, v1beta1.DBaaSConnectionProviderSyncType)
+		return err
+	}, p.Timeout, p.Interval).Should(Succeed())
+	return status
+}
+
+// waitForInstance waits for the provider to report the sync condition of the instance, and returns it
+func waitForInstance(ctx context.Context, p *Provider, converter providerapi.Converter, owner client.Object, providerInstance *unstructured.Unstructured) *v1beta1.DBaaSProviderInstance {
+	var status *v1beta1.DBaaSProviderInstance
+	Eventually(func() error {
+		current, err := getProviderObject(ctx, p, providerInstance, owner)
+		if err != nil {
+			return err
+		}
+		i := converter.NewInstance()
+		if err := parseProviderObject(current, i); err != nil {
+			return err
+		}
+		status = converter.ConvertInstance(i)
+		_, err = CheckSyncCondition(status.Status.Conditions, v1beta1.DBaaSInstanceProviderSyncType)
+		return err
+	}, p.Timeout, p.Interval).Should(Succeed())
+	return status
+}
+
+// getProviderObject reads a provider object, and checks that it is still controlled by its DBaaS object
+func getProviderObject(ctx context.Context, p *Provider, providerObject *unstructured.Unstructured, owner client.Object) (*unstructured.Unstructured, error) {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(providerObject.GroupVersionKind())
+	if err := p.Client.Get(ctx, client.ObjectKeyFromObject(providerObject), current); err != nil {
+		return nil, err
+	}
+	if err := CheckOwnerReference(current, owner); err != nil {
+		return nil, err
+	}
+	return current, nil
+}